@@ -0,0 +1,330 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/IBM/sarama"
+
+	"kafka-topicctl/topicctl"
+)
+
+// aclBinding 是 ACL 文件中的一条绑定，枚举值使用 Kafka 的名称（不区分大小写）
+type aclBinding struct {
+	ResourceType string `json:"resource_type"`          // topic / group / cluster / transactionalid / delegationtoken
+	ResourceName string `json:"resource_name"`          // 资源名，cluster 资源为 kafka-cluster
+	PatternType  string `json:"pattern_type,omitempty"` // literal / prefixed，默认 literal
+	Principal    string `json:"principal"`              // 如 User:alice
+	Host         string `json:"host,omitempty"`         // 默认 *
+	Operation    string `json:"operation"`              // read / write / create / ...
+	Permission   string `json:"permission"`             // allow / deny
+}
+
+// aclFile 是 acl list --out 导出、acl create --in 导入的文件结构
+type aclFile struct {
+	Acls []aclBinding `json:"acls"`
+}
+
+// parseAclEnum 用 sarama 的 UnmarshalText 解析枚举值，field 用于错误信息
+func parseAclEnum(v interface{ UnmarshalText([]byte) error }, field, text string) error {
+	if err := v.UnmarshalText([]byte(text)); err != nil {
+		return fmt.Errorf("无效的 %s %q", field, text)
+	}
+	return nil
+}
+
+// resourceAcl 把绑定转换为 sarama 的 Resource 和 Acl，并拒绝 any / match / unknown 等只能用于过滤的值
+func (b aclBinding) resourceAcl() (sarama.Resource, sarama.Acl, error) {
+	var (
+		res sarama.Resource
+		acl sarama.Acl
+	)
+
+	patternType := b.PatternType
+	if patternType == "" {
+		patternType = "literal"
+	}
+	host := b.Host
+	if host == "" {
+		host = "*"
+	}
+
+	if err := parseAclEnum(&res.ResourceType, "resource_type", b.ResourceType); err != nil {
+		return res, acl, err
+	}
+	if err := parseAclEnum(&res.ResourcePatternType, "pattern_type", patternType); err != nil {
+		return res, acl, err
+	}
+	if err := parseAclEnum(&acl.Operation, "operation", b.Operation); err != nil {
+		return res, acl, err
+	}
+	if err := parseAclEnum(&acl.PermissionType, "permission", b.Permission); err != nil {
+		return res, acl, err
+	}
+
+	switch res.ResourceType {
+	case sarama.AclResourceUnknown, sarama.AclResourceAny:
+		return res, acl, fmt.Errorf("创建 ACL 时 resource_type 不能为 %s", b.ResourceType)
+	}
+	switch res.ResourcePatternType {
+	case sarama.AclPatternLiteral, sarama.AclPatternPrefixed:
+	default:
+		return res, acl, fmt.Errorf("创建 ACL 时 pattern_type 只能为 literal 或 prefixed，当前为 %s", patternType)
+	}
+	switch acl.Operation {
+	case sarama.AclOperationUnknown, sarama.AclOperationAny:
+		return res, acl, fmt.Errorf("创建 ACL 时 operation 不能为 %s", b.Operation)
+	}
+	switch acl.PermissionType {
+	case sarama.AclPermissionAllow, sarama.AclPermissionDeny:
+	default:
+		return res, acl, fmt.Errorf("创建 ACL 时 permission 只能为 allow 或 deny，当前为 %s", b.Permission)
+	}
+	if b.ResourceName == "" || b.Principal == "" {
+		return res, acl, errors.New("创建 ACL 时 resource_name 和 principal 不能为空")
+	}
+
+	res.ResourceName = b.ResourceName
+	acl.Principal = b.Principal
+	acl.Host = host
+	return res, acl, nil
+}
+
+// aclFilter 把命令行过滤条件转换为 sarama.AclFilter；空值表示不过滤（any）
+func (b aclBinding) aclFilter() (sarama.AclFilter, error) {
+	f := sarama.AclFilter{
+		ResourceType:              sarama.AclResourceAny,
+		ResourcePatternTypeFilter: sarama.AclPatternAny,
+		Operation:                 sarama.AclOperationAny,
+		PermissionType:            sarama.AclPermissionAny,
+	}
+
+	enums := []struct {
+		v     interface{ UnmarshalText([]byte) error }
+		field string
+		text  string
+	}{
+		{&f.ResourceType, "resource-type", b.ResourceType},
+		{&f.ResourcePatternTypeFilter, "pattern-type", b.PatternType},
+		{&f.Operation, "operation", b.Operation},
+		{&f.PermissionType, "permission", b.Permission},
+	}
+	for _, e := range enums {
+		if e.text == "" {
+			continue
+		}
+		if err := parseAclEnum(e.v, e.field, e.text); err != nil {
+			return f, err
+		}
+	}
+
+	if b.ResourceName != "" {
+		f.ResourceName = &b.ResourceName
+	}
+	if b.Principal != "" {
+		f.Principal = &b.Principal
+	}
+	if b.Host != "" {
+		f.Host = &b.Host
+	}
+	return f, nil
+}
+
+// bindAclFlags 在 FlagSet 上注册 ACL 的资源和授权参数
+func bindAclFlags(fs *flag.FlagSet) *aclBinding {
+	b := &aclBinding{}
+	fs.StringVar(&b.ResourceType, "resource-type", "", "资源类型: topic / group / cluster / transactionalid / delegationtoken")
+	fs.StringVar(&b.ResourceName, "resource-name", "", "资源名称")
+	fs.StringVar(&b.PatternType, "pattern-type", "", "资源匹配方式: literal / prefixed（过滤时还可用 any / match）")
+	fs.StringVar(&b.Principal, "principal", "", "principal，如 User:alice")
+	fs.StringVar(&b.Host, "host", "", "主机（创建时默认 *）")
+	fs.StringVar(&b.Operation, "operation", "", "操作: read / write / create / delete / alter / describe / all ...")
+	fs.StringVar(&b.Permission, "permission", "", "权限: allow / deny")
+	return b
+}
+
+// bindingsOf 把 sarama 返回的 ResourceAcls 展开为绑定列表，按资源、principal 排序
+func bindingsOf(list []sarama.ResourceAcls) []aclBinding {
+	var bindings []aclBinding
+	for _, ra := range list {
+		for _, a := range ra.Acls {
+			bindings = append(bindings, aclBinding{
+				ResourceType: strings.ToLower(ra.ResourceType.String()),
+				ResourceName: ra.ResourceName,
+				PatternType:  strings.ToLower(ra.ResourcePatternType.String()),
+				Principal:    a.Principal,
+				Host:         a.Host,
+				Operation:    strings.ToLower(a.Operation.String()),
+				Permission:   strings.ToLower(a.PermissionType.String()),
+			})
+		}
+	}
+
+	sort.Slice(bindings, func(i, j int) bool {
+		a, b := bindings[i], bindings[j]
+		if a.ResourceType != b.ResourceType {
+			return a.ResourceType < b.ResourceType
+		}
+		if a.ResourceName != b.ResourceName {
+			return a.ResourceName < b.ResourceName
+		}
+		if a.Principal != b.Principal {
+			return a.Principal < b.Principal
+		}
+		return a.Operation < b.Operation
+	})
+	return bindings
+}
+
+// listAcls 打印匹配过滤条件的 ACL；out 非空时同时写成可供 acl create --in 使用的文件
+func listAcls(conn *Config, filter aclBinding, out string) error {
+	f, err := filter.aclFilter()
+	if err != nil {
+		return err
+	}
+
+	admin, err := newAdmin(conn)
+	if err != nil {
+		return err
+	}
+	defer admin.Close()
+
+	list, err := admin.ListAcls(f)
+	if err != nil {
+		return err
+	}
+	bindings := bindingsOf(list)
+
+	if out != "" {
+		data, err := json.MarshalIndent(aclFile{Acls: bindings}, "", "  ")
+		if err != nil {
+			return err
+		}
+		if out == topicctl.Stdio {
+			_, err = os.Stdout.Write(data)
+			return err
+		}
+		if err := os.WriteFile(out, data, 0644); err != nil {
+			return err
+		}
+		fmt.Printf("🎉 导出完成: %s (%d 条 ACL)\n", out, len(bindings))
+		return nil
+	}
+
+	if len(bindings) == 0 {
+		fmt.Println("没有匹配的 ACL")
+		return nil
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "RESOURCE-TYPE\tRESOURCE-NAME\tPATTERN\tPRINCIPAL\tHOST\tOPERATION\tPERMISSION")
+	for _, b := range bindings {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			b.ResourceType, b.ResourceName, b.PatternType, b.Principal, b.Host, b.Operation, b.Permission)
+	}
+	return w.Flush()
+}
+
+// loadAclFile 读取 ACL 文件
+func loadAclFile(in string) ([]aclBinding, error) {
+	data, err := os.ReadFile(in)
+	if err != nil {
+		return nil, err
+	}
+	var file aclFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("解析 %s 失败: %w", in, err)
+	}
+	return file.Acls, nil
+}
+
+// createAcls 先校验全部绑定的枚举值，全部合法后再逐条创建
+func createAcls(conn *Config, bindings []aclBinding) error {
+	type pending struct {
+		res sarama.Resource
+		acl sarama.Acl
+	}
+
+	var (
+		acls     []pending
+		problems []string
+	)
+	for i, b := range bindings {
+		res, acl, err := b.resourceAcl()
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("acls[%d]: %v", i, err))
+			continue
+		}
+		acls = append(acls, pending{res, acl})
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("ACL 校验失败:\n  %s", strings.Join(problems, "\n  "))
+	}
+
+	admin, err := newAdmin(conn)
+	if err != nil {
+		return err
+	}
+	defer admin.Close()
+
+	for _, p := range acls {
+		if err := admin.CreateACL(p.res, p.acl); err != nil {
+			return fmt.Errorf("创建 ACL 失败: %s %s %s: %w", p.acl.Principal, p.res.ResourceName, p.acl.Operation.String(), err)
+		}
+		fmt.Printf("✅ 创建 ACL: %s %s %s on %s:%s\n",
+			p.acl.PermissionType.String(), p.acl.Principal, p.acl.Operation.String(), p.res.ResourceType.String(), p.res.ResourceName)
+	}
+	return nil
+}
+
+// deleteAcls 删除匹配过滤条件的 ACL，执行前列出匹配项并要求确认
+func deleteAcls(conn *Config, filter aclBinding, yes bool) error {
+	if filter == (aclBinding{}) {
+		return errors.New("删除 ACL 至少需要一个过滤条件")
+	}
+	f, err := filter.aclFilter()
+	if err != nil {
+		return err
+	}
+
+	admin, err := newAdmin(conn)
+	if err != nil {
+		return err
+	}
+	defer admin.Close()
+
+	list, err := admin.ListAcls(f)
+	if err != nil {
+		return err
+	}
+	bindings := bindingsOf(list)
+	if len(bindings) == 0 {
+		fmt.Println("没有匹配的 ACL")
+		return nil
+	}
+
+	items := make([]string, 0, len(bindings))
+	for _, b := range bindings {
+		items = append(items, fmt.Sprintf("%s %s %s on %s:%s (%s)", b.Permission, b.Principal, b.Operation, b.ResourceType, b.ResourceName, b.PatternType))
+	}
+	if !yes && !confirm("ACL", items) {
+		return errCanceled
+	}
+
+	matched, err := admin.DeleteACL(f, false)
+	if err != nil {
+		return err
+	}
+	for _, m := range matched {
+		if m.Err != sarama.ErrNoError {
+			return fmt.Errorf("删除 ACL 失败: %s %s: %w", m.Principal, m.ResourceName, m.Err)
+		}
+	}
+	fmt.Printf("🗑️  删除 ACL: %d 条\n", len(matched))
+	return nil
+}
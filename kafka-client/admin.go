@@ -0,0 +1,227 @@
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/IBM/sarama"
+)
+
+// Config 是各子命令共用的连接配置
+type Config struct {
+	Bootstrap     string
+	BootstrapSRV  string // 从 DNS SRV 记录解析 broker 列表，见 resolveBootstrapSRV
+	KafkaVersion  string
+	Timeout       time.Duration
+	Retries       int
+	RetryBackoff  time.Duration
+	SASLMechanism string
+	SASLUser      string
+	SASLPassword  string
+
+	OAuthToken        string
+	OAuthTokenURL     string
+	OAuthClientID     string
+	OAuthClientSecret string
+
+	TLS         bool
+	TLSCA       string
+	TLSCert     string
+	TLSKey      string
+	TLSInsecure bool
+
+	ConfigFile string // --config 指定的配置文件
+	Debug      bool   // 输出 sarama 内部日志
+
+	AllowInternal bool // 允许删除等破坏性操作作用于内部 topic，见 checkDeletable
+}
+
+// bindConnFlags 在子命令的 FlagSet 上注册公共连接参数
+func bindConnFlags(fs *flag.FlagSet) *Config {
+	c := bindClientFlags(fs)
+	fs.StringVar(&c.Bootstrap, "bootstrap", os.Getenv("KAFKA_BOOTSTRAP"), "Kafka bootstrap server（多个用逗号分隔，未指定时读取环境变量 KAFKA_BOOTSTRAP）")
+	fs.StringVar(&c.BootstrapSRV, "bootstrap-srv", "", "从 DNS SRV 记录解析 broker 列表，如 _kafka._tcp.example.com（按优先级和权重排序，不能与 --bootstrap 同时使用）")
+	return c
+}
+
+// bindClientFlags 注册除 --bootstrap 以外的连接参数，供同时连接多个集群的子命令共用
+func bindClientFlags(fs *flag.FlagSet) *Config {
+	c := &Config{}
+	fs.StringVar(&c.ConfigFile, "config", "", "连接参数配置文件（YAML，默认读取 ~/"+defaultConfigFile+"，命令行参数优先）")
+	fs.StringVar(&c.KafkaVersion, "kafka-version", "2.4.0", "Kafka 协议版本，如 2.4.0、3.6.0")
+	fs.DurationVar(&c.Timeout, "timeout", 10*time.Second, "admin 请求和建立连接的超时时间，如 30s、2m")
+	fs.IntVar(&c.Retries, "retries", 0, "admin 请求遇到瞬时错误时的重试次数")
+	fs.DurationVar(&c.RetryBackoff, "retry-backoff", 500*time.Millisecond, "首次重试前的等待时间，之后每次翻倍")
+	fs.StringVar(&c.SASLMechanism, "sasl-mechanism", "plain", "SASL 机制: plain / scram-sha-256 / scram-sha-512 / oauthbearer")
+	fs.StringVar(&c.SASLUser, "sasl-user", "", "SASL 用户名")
+	fs.StringVar(&c.SASLPassword, "sasl-password", "", "SASL 密码")
+	fs.StringVar(&c.OAuthToken, "oauth-token", "", "oauthbearer 使用的固定 bearer token")
+	fs.StringVar(&c.OAuthTokenURL, "oauth-token-url", "", "oauthbearer 的 token endpoint（client credentials 方式）")
+	fs.StringVar(&c.OAuthClientID, "oauth-client-id", "", "oauthbearer 的 client id")
+	fs.StringVar(&c.OAuthClientSecret, "oauth-client-secret", "", "oauthbearer 的 client secret")
+	fs.BoolVar(&c.TLS, "tls", false, "启用 TLS")
+	fs.StringVar(&c.TLSCA, "tls-ca", "", "CA 证书文件（PEM）")
+	fs.StringVar(&c.TLSCert, "tls-cert", "", "客户端证书文件（PEM）")
+	fs.StringVar(&c.TLSKey, "tls-key", "", "客户端私钥文件（PEM）")
+	fs.BoolVar(&c.TLSInsecure, "tls-insecure", false, "跳过服务端证书校验（仅限开发环境）")
+	fs.BoolVar(&c.Debug, "debug", false, "把 sarama 内部日志（连接、认证、broker 发现）输出到 stderr")
+	return c
+}
+
+// brokers 返回解析后的 broker 列表
+func (c *Config) brokers() []string {
+	return splitList(c.Bootstrap)
+}
+
+// version 解析 --kafka-version
+func (c *Config) version() (sarama.KafkaVersion, error) {
+	v, err := sarama.ParseKafkaVersion(c.KafkaVersion)
+	if err != nil {
+		return v, fmt.Errorf("无效的 --kafka-version %q: %w", c.KafkaVersion, err)
+	}
+	return v, nil
+}
+
+// checkAllowUnspecified 检查 --allow-unspecified 所需的协议版本：以 -1 提交分区数 / 副本数需要 Kafka >= 2.4（KIP-464）
+func (c *Config) checkAllowUnspecified() error {
+	v, err := c.version()
+	if err != nil {
+		return err
+	}
+	if !v.IsAtLeast(sarama.V2_4_0_0) {
+		return usageError(fmt.Sprintf("--allow-unspecified 需要 Kafka >= 2.4（KIP-464），当前 --kafka-version 为 %s", c.KafkaVersion))
+	}
+	return nil
+}
+
+// saramaConfig 根据连接参数构建 sarama.Config
+func (c *Config) saramaConfig() (*sarama.Config, error) {
+	version, err := c.version()
+	if err != nil {
+		return nil, err
+	}
+
+	// sarama.Logger 默认丢弃日志，--debug 时输出到 stderr
+	if c.Debug {
+		sarama.Logger = log.New(os.Stderr, "[sarama] ", log.LstdFlags)
+	}
+
+	cfg := sarama.NewConfig()
+	cfg.Version = version
+	cfg.Admin.Timeout = c.Timeout
+	cfg.Net.DialTimeout = c.Timeout
+
+	if strings.EqualFold(c.SASLMechanism, "oauthbearer") {
+		provider, err := c.newTokenProvider()
+		if err != nil {
+			return nil, err
+		}
+		cfg.Net.SASL.Enable = true
+		cfg.Net.SASL.Mechanism = sarama.SASLTypeOAuth
+		cfg.Net.SASL.TokenProvider = provider
+	} else if c.SASLUser != "" || c.SASLPassword != "" {
+		if c.SASLUser == "" || c.SASLPassword == "" {
+			return nil, errors.New("--sasl-user 和 --sasl-password 必须同时指定")
+		}
+		cfg.Net.SASL.Enable = true
+		switch strings.ToLower(c.SASLMechanism) {
+		case "", "plain":
+			cfg.Net.SASL.Mechanism = sarama.SASLTypePlaintext
+		case "scram-sha-256":
+			cfg.Net.SASL.Mechanism = sarama.SASLTypeSCRAMSHA256
+			cfg.Net.SASL.SCRAMClientGeneratorFunc = func() sarama.SCRAMClient {
+				return &XDGSCRAMClient{HashGeneratorFcn: SHA256}
+			}
+		case "scram-sha-512":
+			cfg.Net.SASL.Mechanism = sarama.SASLTypeSCRAMSHA512
+			cfg.Net.SASL.SCRAMClientGeneratorFunc = func() sarama.SCRAMClient {
+				return &XDGSCRAMClient{HashGeneratorFcn: SHA512}
+			}
+		default:
+			return nil, fmt.Errorf("不支持的 --sasl-mechanism %q（可选 plain / scram-sha-256 / scram-sha-512 / oauthbearer）", c.SASLMechanism)
+		}
+		cfg.Net.SASL.User = c.SASLUser
+		cfg.Net.SASL.Password = c.SASLPassword
+	}
+
+	if c.TLS {
+		tlsCfg, err := c.tlsConfig()
+		if err != nil {
+			return nil, err
+		}
+		cfg.Net.TLS.Enable = true
+		cfg.Net.TLS.Config = tlsCfg
+	}
+
+	return cfg, nil
+}
+
+// newAdmin 创建 Sarama ClusterAdmin；无法连接时返回 connectError
+func newAdmin(c *Config) (sarama.ClusterAdmin, error) {
+	cfg, err := c.saramaConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	admin, err := sarama.NewClusterAdmin(c.brokers(), cfg)
+	if err != nil {
+		return nil, &connectError{Cluster: c.Bootstrap, Err: err}
+	}
+	if c.Timeout > 0 {
+		admin = &timeoutAdmin{ClusterAdmin: admin, timeout: c.Timeout}
+	}
+	if c.Retries > 0 {
+		return &retryAdmin{ClusterAdmin: admin, retries: c.Retries, backoff: c.RetryBackoff}, nil
+	}
+	return admin, nil
+}
+
+// newClient 创建 Sarama Client，用于查询 offset 等 admin 不提供的操作；无法连接时返回 connectError
+func newClient(c *Config) (sarama.Client, error) {
+	cfg, err := c.saramaConfig()
+	if err != nil {
+		return nil, err
+	}
+	client, err := sarama.NewClient(c.brokers(), cfg)
+	if err != nil {
+		return nil, &connectError{Cluster: c.Bootstrap, Err: err}
+	}
+	return client, nil
+}
+
+// tlsConfig 根据 TLS 参数构建 *tls.Config
+func (c *Config) tlsConfig() (*tls.Config, error) {
+	tlsCfg := &tls.Config{InsecureSkipVerify: c.TLSInsecure}
+
+	if c.TLSCA != "" {
+		pem, err := os.ReadFile(c.TLSCA)
+		if err != nil {
+			return nil, fmt.Errorf("读取 CA 证书 %s 失败: %w", c.TLSCA, err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("解析 CA 证书 %s 失败: 未找到有效的 PEM 证书", c.TLSCA)
+		}
+		tlsCfg.RootCAs = pool
+	}
+
+	if c.TLSCert != "" || c.TLSKey != "" {
+		if c.TLSCert == "" || c.TLSKey == "" {
+			return nil, errors.New("--tls-cert 和 --tls-key 必须同时指定")
+		}
+		cert, err := tls.LoadX509KeyPair(c.TLSCert, c.TLSKey)
+		if err != nil {
+			return nil, fmt.Errorf("加载客户端证书 %s / %s 失败: %w", c.TLSCert, c.TLSKey, err)
+		}
+		tlsCfg.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsCfg, nil
+}
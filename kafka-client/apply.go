@@ -0,0 +1,213 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"kafka-topicctl/topicctl"
+)
+
+// errCanceled 表示用户在确认提示中取消了操作
+var errCanceled = errors.New("已取消")
+
+// errPlanPending 表示 --only-diff 时存在待执行的操作，调用方以 exitChanged 退出
+var errPlanPending = errors.New("存在待执行的变更")
+
+// applyOptions 控制 applyFile 的行为
+type applyOptions struct {
+	Prune                bool // 删除集群中存在而文件中没有的非内部 topic
+	Yes                  bool // 删除前不做交互确认
+	DryRun               bool // 只打印对比结果
+	OnlyDiff             bool // 只打印按执行顺序排列的操作计划，有待执行的操作时返回 errPlanPending
+	DeleteMissingConfigs bool // 删除文件中没有的配置覆盖项
+	AllowUnspecified     bool // 允许创建未指定分区数 / 副本数的 topic，由 broker 使用默认值
+}
+
+// planAction 是执行计划中的一步
+type planAction struct {
+	Action string // create / alter-partitions / alter-config / delete-config / delete
+	Topic  string
+	Detail string
+}
+
+// buildPlan 把对比结果转换为 apply 实际会执行的操作，按执行顺序排列: 创建、扩容分区、修改配置、删除 topic；
+// apply 不会执行的差异（副本数、缩减分区、未指定 --prune / --delete-missing-configs 时的删除）记为说明
+func buildPlan(d *topicctl.Diff, want []topicctl.Topic, opts applyOptions) (actions []planAction, notes []string) {
+	byName := make(map[string]topicctl.Topic, len(want))
+	for _, t := range want {
+		byName[t.Name] = t
+	}
+
+	for _, name := range d.OnlyInFile {
+		t := byName[name]
+		actions = append(actions, planAction{"create", name, fmt.Sprintf("partitions=%d, replication_factor=%d, configs=%d", t.Partitions, t.ReplicationFactor, len(t.Configs))})
+	}
+
+	var partitions, configs []planAction
+	for _, c := range d.Changed {
+		for _, f := range c.Changes {
+			switch {
+			case f.Field == "partitions":
+				if old, _ := strconv.Atoi(f.Old); old > 0 {
+					if n, _ := strconv.Atoi(f.New); n < old {
+						notes = append(notes, fmt.Sprintf("%s: Kafka 不支持缩减分区（%s -> %s），不会修改", c.Name, f.Old, f.New))
+						continue
+					}
+				}
+				partitions = append(partitions, planAction{"alter-partitions", c.Name, f.Old + " -> " + f.New})
+			case f.Field == "replication_factor":
+				notes = append(notes, fmt.Sprintf("%s: apply 不会修改副本数（%s -> %s），请使用 reassign", c.Name, f.Old, f.New))
+			case strings.HasPrefix(f.Field, "configs."):
+				key := strings.TrimPrefix(f.Field, "configs.")
+				if _, inFile := byName[c.Name].Configs[key]; !inFile {
+					if !opts.DeleteMissingConfigs {
+						notes = append(notes, fmt.Sprintf("%s: 配置 %s 不在文件中，未指定 --delete-missing-configs，保留集群上的值", c.Name, key))
+						continue
+					}
+					configs = append(configs, planAction{"delete-config", c.Name, fmt.Sprintf("%s（当前 %s）", key, f.Old)})
+					continue
+				}
+				configs = append(configs, planAction{"alter-config", c.Name, fmt.Sprintf("%s: %s -> %s", key, f.Old, f.New)})
+			}
+		}
+	}
+	actions = append(actions, partitions...)
+	actions = append(actions, configs...)
+
+	if opts.Prune {
+		for _, name := range d.OnlyInCluster {
+			actions = append(actions, planAction{"delete", name, ""})
+		}
+	} else if len(d.OnlyInCluster) > 0 {
+		notes = append(notes, fmt.Sprintf("仅存在于集群的 %d 个 topic 不会被删除（未指定 --prune）", len(d.OnlyInCluster)))
+	}
+	return actions, notes
+}
+
+// printPlan 按执行顺序编号输出操作计划及说明
+func printPlan(actions []planAction, notes []string) {
+	if len(actions) > 0 {
+		fmt.Printf("📋 执行计划 (%d 步):\n", len(actions))
+		for i, a := range actions {
+			line := fmt.Sprintf("  %d. %-16s %s", i+1, a.Action, a.Topic)
+			if a.Detail != "" {
+				line += "  " + a.Detail
+			}
+			fmt.Println(line)
+		}
+	}
+	for _, n := range notes {
+		fmt.Println("ℹ️  " + n)
+	}
+}
+
+// applyFile 让集群与文件一致：创建缺失的 topic，修改分区数和配置；
+// Prune 时还会删除集群中存在而文件中没有的非内部 topic，DeleteMissingConfigs 时删除文件中没有的配置覆盖项。执行前先打印计划
+func applyFile(ctx context.Context, conn *Config, in, format string, opts applyOptions) error {
+	file, err := topicctl.LoadFile(in, format)
+	if err != nil {
+		return err
+	}
+
+	admin, err := newAdmin(conn)
+	if err != nil {
+		return err
+	}
+	defer admin.Close()
+
+	// 内部 topic 不参与对比，因此也永远不会被 --prune 删除
+	have, err := topicctl.ListTopics(admin, &topicctl.Filter{ExcludeInternal: true})
+	if err != nil {
+		return err
+	}
+
+	want := append([]topicctl.Topic(nil), file.Topics...)
+	sort.Slice(want, func(i, j int) bool {
+		return want[i].Name < want[j].Name
+	})
+	d := topicctl.DiffTopics(want, have)
+
+	if opts.OnlyDiff {
+		actions, notes := buildPlan(d, want, opts)
+		if len(actions) == 0 {
+			printPlan(nil, notes)
+			fmt.Println("🎉 没有待执行的操作")
+			return nil
+		}
+		printPlan(actions, notes)
+		return errPlanPending
+	}
+
+	if d.Empty() {
+		fmt.Println("🎉 集群已与文件一致，无需变更")
+		return nil
+	}
+
+	fmt.Println("📋 执行计划:")
+	printDiff(d)
+	if len(d.OnlyInCluster) > 0 && !opts.Prune {
+		fmt.Println("ℹ️  未指定 --prune，仅存在于集群的 topic 不会被删除")
+	}
+	if opts.DryRun {
+		fmt.Println("🎉 dry-run 完成，未做任何修改")
+		return nil
+	}
+
+	var toDelete []string
+	if opts.Prune {
+		toDelete = d.OnlyInCluster
+		if err := checkDeletable(conn, toDelete); err != nil {
+			return err
+		}
+	}
+	if len(toDelete) > 0 && !opts.Yes && !confirm("topic", toDelete) {
+		return errCanceled
+	}
+
+	// 只把需要创建或修改的 topic 交给 ImportTopics，已一致的 topic 不再逐个输出
+	pending := make(map[string]bool)
+	for _, name := range d.OnlyInFile {
+		pending[name] = true
+	}
+	for _, c := range d.Changed {
+		pending[c.Name] = true
+	}
+	var topics []topicctl.Topic
+	for _, t := range want {
+		if pending[t.Name] {
+			topics = append(topics, t)
+		}
+	}
+
+	if len(topics) > 0 {
+		res, err := topicctl.ImportTopics(ctx, admin, &topicctl.ExportFile{Topics: topics}, topicctl.ImportOptions{
+			IfNotExists:     true,
+			AlterPartitions: true,
+			AlterConfigs:    true,
+			Log:             lg.log,
+
+			DeleteMissingConfigs: opts.DeleteMissingConfigs,
+			AllowUnspecified:     opts.AllowUnspecified,
+		})
+		if err != nil {
+			// 已有 topic 创建或修改成功时属于部分失败
+			return partial(err, len(res.Created)+len(res.Altered))
+		}
+	}
+
+	if len(toDelete) > 0 {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("apply 已中断，未执行删除: %w", err)
+		}
+		// 此时创建和修改已完成，删除失败属于部分失败
+		if deleted, err := deleteTopics(conn, toDelete); err != nil {
+			return partial(err, deleted+len(topics))
+		}
+	}
+	fmt.Println("🎉 apply 完成")
+	return nil
+}
@@ -0,0 +1,207 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/IBM/sarama"
+)
+
+// resourceTypes 是 --resource-type 支持的配置资源类型
+var resourceTypes = map[string]sarama.ConfigResourceType{
+	"topic":         sarama.TopicResource,
+	"broker":        sarama.BrokerResource,
+	"broker-logger": sarama.BrokerLoggerResource,
+}
+
+// configResource 是一个要查询或修改配置的资源，label 用于输出
+type configResource struct {
+	res   sarama.ConfigResource
+	label string
+}
+
+// resourceTarget 描述 broker-config 的操作对象: --resource-type 以及 --resource-name、--broker / --all-brokers 或 --cluster-default
+type resourceTarget struct {
+	Type       string
+	Name       string
+	Broker     int
+	AllBrokers bool
+
+	ClusterDefault bool // 集群级动态默认配置，即资源名为空的 broker 资源，对没有单独覆盖的全部 broker 生效
+}
+
+// validate 检查资源类型和目标参数的组合，返回可以直接交给 fatal 的用法错误
+func (t resourceTarget) validate() error {
+	if _, ok := resourceTypes[t.Type]; !ok {
+		return usageError(fmt.Sprintf("不支持的 --resource-type %q（可选 topic / broker / broker-logger）", t.Type))
+	}
+	byBroker := t.Broker >= 0 || t.AllBrokers
+	if t.ClusterDefault {
+		switch {
+		case t.Type != "broker":
+			return usageError("--cluster-default 只能用于 --resource-type broker")
+		case byBroker || t.Name != "":
+			return usageError("--cluster-default 不能与 --broker / --all-brokers / --resource-name 同时使用")
+		}
+		return nil
+	}
+	switch {
+	case t.Type == "topic" && byBroker:
+		return usageError("--resource-type topic 不能与 --broker / --all-brokers 同时使用，请用 --resource-name 指定 topic")
+	case t.Type == "topic" && t.Name == "":
+		return usageError("--resource-type topic 必须用 --resource-name 指定 topic")
+	case t.Name != "" && byBroker:
+		return usageError("--resource-name 不能与 --broker / --all-brokers 同时使用")
+	case t.Type != "topic" && t.Name == "" && !byBroker:
+		return usageError("必须用 --broker、--all-brokers、--resource-name 或 --cluster-default 指定 broker")
+	case t.Type != "topic" && t.Name != "":
+		if _, err := strconv.Atoi(t.Name); err != nil {
+			return usageError(fmt.Sprintf("--resource-type %s 的 --resource-name 必须是 broker ID，当前为 %q", t.Type, t.Name))
+		}
+	}
+	return nil
+}
+
+// resources 返回要操作的配置资源；--all-brokers 时为集群中的全部 broker
+func (t resourceTarget) resources(admin sarama.ClusterAdmin) ([]configResource, error) {
+	typ := resourceTypes[t.Type]
+	one := func(name string) []configResource {
+		return []configResource{{res: sarama.ConfigResource{Type: typ, Name: name}, label: t.Type + " " + name}}
+	}
+	switch {
+	case t.ClusterDefault:
+		return []configResource{{res: sarama.ConfigResource{Type: typ, Name: ""}, label: "broker 集群默认值"}}, nil
+	case t.Name != "":
+		return one(t.Name), nil
+	case !t.AllBrokers:
+		return one(strconv.Itoa(t.Broker)), nil
+	}
+
+	ids, err := allBrokerIDs(admin)
+	if err != nil {
+		return nil, err
+	}
+	var out []configResource
+	for _, id := range ids {
+		out = append(out, one(strconv.Itoa(int(id)))...)
+	}
+	return out, nil
+}
+
+// allBrokerIDs 返回集群中全部 broker 的 ID，按升序排列
+func allBrokerIDs(admin sarama.ClusterAdmin) ([]int32, error) {
+	brokers, _, err := admin.DescribeCluster()
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]int32, 0, len(brokers))
+	for _, b := range brokers {
+		ids = append(ids, b.ID())
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids, nil
+}
+
+// describeResourceConfig 查询资源的全部配置，按名称排序
+func describeResourceConfig(admin sarama.ClusterAdmin, r configResource) ([]sarama.ConfigEntry, error) {
+	entries, err := admin.DescribeConfig(r.res)
+	if err != nil {
+		return nil, fmt.Errorf("查询 %s 的配置失败: %w", r.label, err)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name < entries[j].Name })
+	return entries, nil
+}
+
+// getBrokerConfig 打印资源配置的名称、值和来源；敏感配置不显示值
+func getBrokerConfig(conn *Config, target resourceTarget) error {
+	admin, err := newAdmin(conn)
+	if err != nil {
+		return err
+	}
+	defer admin.Close()
+
+	resources, err := target.resources(admin)
+	if err != nil {
+		return err
+	}
+
+	for i, r := range resources {
+		entries, err := describeResourceConfig(admin, r)
+		if err != nil {
+			return err
+		}
+
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Printf("%s:\n", r.label)
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "NAME\tVALUE\tSOURCE")
+		for _, e := range entries {
+			value := e.Value
+			if e.Sensitive {
+				value = "******"
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\n", e.Name, value, e.Source)
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setBrokerConfig 用 IncrementalAlterConfig 修改资源配置，只改动指定的 key，并打印修改前后的值
+func setBrokerConfig(conn *Config, target resourceTarget, configs map[string]string) error {
+	admin, err := newAdmin(conn)
+	if err != nil {
+		return err
+	}
+	defer admin.Close()
+
+	resources, err := target.resources(admin)
+	if err != nil {
+		return err
+	}
+
+	keys := make([]string, 0, len(configs))
+	for k := range configs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, r := range resources {
+		entries, err := describeResourceConfig(admin, r)
+		if err != nil {
+			return err
+		}
+		before := make(map[string]string, len(entries))
+		for _, e := range entries {
+			before[e.Name] = e.Value
+		}
+
+		changes := make(map[string]sarama.IncrementalAlterConfigsEntry, len(keys))
+		for _, k := range keys {
+			v := configs[k]
+			changes[k] = sarama.IncrementalAlterConfigsEntry{
+				Operation: sarama.IncrementalAlterConfigsOperationSet,
+				Value:     &v,
+			}
+		}
+		if err := admin.IncrementalAlterConfig(r.res.Type, r.res.Name, changes, false); err != nil {
+			return fmt.Errorf("修改 %s 的配置失败: %w", r.label, err)
+		}
+
+		for _, k := range keys {
+			old, ok := before[k]
+			if !ok {
+				old = "<未设置>"
+			}
+			fmt.Printf("🔧 %s: %s: %s -> %s\n", r.label, k, old, configs[k])
+		}
+	}
+	return nil
+}
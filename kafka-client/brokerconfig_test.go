@@ -0,0 +1,52 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/IBM/sarama"
+)
+
+func TestResourceTargetValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		target  resourceTarget
+		wantErr bool
+	}{
+		{name: "broker by id", target: resourceTarget{Type: "broker", Broker: 1}},
+		{name: "all brokers", target: resourceTarget{Type: "broker", Broker: -1, AllBrokers: true}},
+		{name: "cluster default", target: resourceTarget{Type: "broker", Broker: -1, ClusterDefault: true}},
+		{name: "topic", target: resourceTarget{Type: "topic", Name: "orders", Broker: -1}},
+		{name: "broker without target", target: resourceTarget{Type: "broker", Broker: -1}, wantErr: true},
+		{name: "cluster default with broker", target: resourceTarget{Type: "broker", Broker: 1, ClusterDefault: true}, wantErr: true},
+		{name: "cluster default with all brokers", target: resourceTarget{Type: "broker", Broker: -1, AllBrokers: true, ClusterDefault: true}, wantErr: true},
+		{name: "cluster default with resource name", target: resourceTarget{Type: "broker", Name: "1", Broker: -1, ClusterDefault: true}, wantErr: true},
+		{name: "cluster default for broker-logger", target: resourceTarget{Type: "broker-logger", Broker: -1, ClusterDefault: true}, wantErr: true},
+		{name: "cluster default for topic", target: resourceTarget{Type: "topic", Name: "orders", Broker: -1, ClusterDefault: true}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.target.validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validate() = %v, wantErr %v", err, tt.wantErr)
+			}
+			var ue usageError
+			if err != nil && !errors.As(err, &ue) {
+				t.Errorf("validate() = %T, want usageError", err)
+			}
+		})
+	}
+}
+
+// 集群级默认配置是资源名为空的 broker 资源，不需要查询集群
+func TestResourceTargetClusterDefault(t *testing.T) {
+	target := resourceTarget{Type: "broker", Broker: -1, ClusterDefault: true}
+	resources, err := target.resources(nil)
+	if err != nil {
+		t.Fatalf("resources: %v", err)
+	}
+	want := sarama.ConfigResource{Type: sarama.BrokerResource, Name: ""}
+	if len(resources) != 1 || resources[0].res.Type != want.Type || resources[0].res.Name != want.Name {
+		t.Fatalf("resources = %+v, want one %+v", resources, want)
+	}
+}
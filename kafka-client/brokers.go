@@ -0,0 +1,46 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"sort"
+	"text/tabwriter"
+)
+
+// listBrokers 打印 controller ID 和所有 broker 的 ID、主机、端口、机架；controllerOnly 时只打印 controller ID
+func listBrokers(conn *Config, controllerOnly bool) error {
+	admin, err := newAdmin(conn)
+	if err != nil {
+		return err
+	}
+	defer admin.Close()
+
+	brokers, controller, err := admin.DescribeCluster()
+	if err != nil {
+		return err
+	}
+
+	if controllerOnly {
+		fmt.Println(controller)
+		return nil
+	}
+
+	sort.Slice(brokers, func(i, j int) bool { return brokers[i].ID() < brokers[j].ID() })
+
+	fmt.Printf("controller: %d\n\n", controller)
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tHOST\tPORT\tRACK")
+	for _, b := range brokers {
+		host, port, err := net.SplitHostPort(b.Addr())
+		if err != nil {
+			host, port = b.Addr(), "-"
+		}
+		rack := b.Rack()
+		if rack == "" {
+			rack = "-"
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", b.ID(), host, port, rack)
+	}
+	return w.Flush()
+}
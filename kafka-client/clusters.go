@@ -0,0 +1,64 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// clusterList 是可重复的集群参数，每次出现表示一个集群（值本身可以是逗号分隔的多个 broker）
+type clusterList []string
+
+// String 实现 flag.Value
+func (c *clusterList) String() string {
+	return strings.Join(*c, " ")
+}
+
+// Set 实现 flag.Value
+func (c *clusterList) Set(v string) error {
+	if strings.TrimSpace(v) == "" {
+		return errors.New("集群地址不能为空")
+	}
+	*c = append(*c, v)
+	return nil
+}
+
+// connectError 表示无法连接到某个集群，用于与连接成功后的业务错误区分
+type connectError struct {
+	Cluster string
+	Err     error
+}
+
+// Error 返回带集群地址的描述
+func (e *connectError) Error() string {
+	return fmt.Sprintf("无法连接集群 %s: %v", e.Cluster, e.Err)
+}
+
+// Unwrap 支持 errors.Is / errors.As
+func (e *connectError) Unwrap() error {
+	return e.Err
+}
+
+// skipUnreachable 在 continueOnConnectError 且 err 是连接失败时记录该集群并返回 true，调用方跳过该集群继续处理
+func skipUnreachable(err error, continueOnConnectError bool, unreachable *[]string) bool {
+	var ce *connectError
+	if !continueOnConnectError || !errors.As(err, &ce) {
+		return false
+	}
+	fmt.Fprintf(os.Stderr, "⚠️  %v，已跳过\n", err)
+	*unreachable = append(*unreachable, ce.Cluster)
+	return true
+}
+
+// reportUnreachable 汇总无法连接的集群；存在时以 exitConnection 退出
+func reportUnreachable(unreachable []string) {
+	if len(unreachable) == 0 {
+		return
+	}
+	fmt.Fprintf(os.Stderr, "❌ %d 个集群无法连接:\n", len(unreachable))
+	for _, c := range unreachable {
+		fmt.Fprintf(os.Stderr, "  - %s\n", c)
+	}
+	exit(exitConnection)
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+
+	"kafka-topicctl/topicctl"
+)
+
+// compareTopics 对比左右两侧的 topic；
+// 结果中 OnlyInCluster 为仅存在于左侧的 topic，OnlyInFile 为仅存在于右侧的 topic，字段变化为 左侧值 -> 右侧值
+func compareTopics(left, right []topicctl.Topic) *topicctl.Diff {
+	return topicctl.DiffTopics(right, left)
+}
+
+// listClusterTopics 连接集群并列出通过过滤的 topic；连接失败时返回 connectError
+func listClusterTopics(conn *Config, filter *topicctl.Filter) ([]topicctl.Topic, error) {
+	admin, err := newAdmin(conn)
+	if err != nil {
+		return nil, err
+	}
+	defer admin.Close()
+
+	return topicctl.ListTopics(admin, filter)
+}
+
+// printCompare 按类别输出两个集群的对比结果
+func printCompare(d *topicctl.Diff, left, right string) {
+	if len(d.OnlyInCluster) > 0 {
+		fmt.Printf("⬅️  仅存在于 %s (%d):\n", left, len(d.OnlyInCluster))
+		for _, name := range d.OnlyInCluster {
+			fmt.Printf("  - %s\n", name)
+		}
+	}
+	if len(d.OnlyInFile) > 0 {
+		fmt.Printf("➡️  仅存在于 %s (%d):\n", right, len(d.OnlyInFile))
+		for _, name := range d.OnlyInFile {
+			fmt.Printf("  + %s\n", name)
+		}
+	}
+	if len(d.Changed) > 0 {
+		fmt.Printf("✏️  存在差异 (%d)，格式为 %s -> %s:\n", len(d.Changed), left, right)
+		for _, c := range d.Changed {
+			fmt.Printf("  ~ %s\n", c.Name)
+			for _, f := range c.Changes {
+				fmt.Printf("      %s: %s -> %s\n", f.Field, f.Old, f.New)
+			}
+		}
+	}
+}
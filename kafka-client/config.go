@@ -0,0 +1,110 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// defaultConfigFile 是未指定 --config 时尝试读取的配置文件，不存在则忽略
+const defaultConfigFile = ".kafka-topicctl.yaml"
+
+// parseFlags 解析子命令参数，并用配置文件补齐命令行未显式指定的连接参数
+func parseFlags(flags *flag.FlagSet, c *Config) {
+	parseArgs(flags, c, os.Args[2:])
+}
+
+// parseArgs 与 parseFlags 相同，但解析指定的参数，供带二级动作的子命令使用
+func parseArgs(flags *flag.FlagSet, c *Config, args []string) {
+	parseOnly(flags, args)
+	if err := c.loadFile(flags); err != nil {
+		fatal(err)
+	}
+	explicit := false
+	flags.Visit(func(f *flag.Flag) { explicit = explicit || f.Name == "bootstrap" })
+	if err := c.resolveBootstrapSRV(explicit); err != nil {
+		fatal(err)
+	}
+}
+
+// parseOnly 解析参数但不读取配置文件；-h 时以 exitOK 退出，参数错误时以 exitUsage 退出（错误和用法已由 FlagSet 输出）
+func parseOnly(flags *flag.FlagSet, args []string) {
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			exit(exitOK)
+		}
+		exit(exitUsage)
+	}
+}
+
+// loadFile 读取 YAML 配置文件，key 与连接参数的 flag 名一致，例如:
+//
+//	bootstrap: b1:9092,b2:9092    # 或 bootstrap-srv: _kafka._tcp.example.com
+//	kafka-version: 3.6.0
+//	timeout: 30s
+//	sasl-mechanism: scram-sha-512
+//	sasl-user: admin
+//	tls: true
+//	tls-ca: /etc/kafka/ca.pem
+//
+// 命令行显式指定的参数优先于配置文件。
+func (c *Config) loadFile(flags *flag.FlagSet) error {
+	path, explicit := c.ConfigFile, c.ConfigFile != ""
+	if !explicit {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil
+		}
+		path = filepath.Join(home, defaultConfigFile)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if !explicit && errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return fmt.Errorf("读取配置文件 %s 失败: %w", path, err)
+	}
+
+	var values map[string]string
+	if err := yaml.Unmarshal(data, &values); err != nil {
+		return fmt.Errorf("解析配置文件 %s 失败: %w", path, err)
+	}
+
+	known := connFlagNames()
+	set := make(map[string]bool)
+	flags.Visit(func(f *flag.Flag) { set[f.Name] = true })
+
+	for key, value := range values {
+		if !known[key] {
+			return fmt.Errorf("配置文件 %s: 未知的配置项 %q", path, key)
+		}
+		// 当前子命令没有该参数（如 migrate 没有 --bootstrap），或命令行已显式指定
+		if flags.Lookup(key) == nil || set[key] {
+			continue
+		}
+		if err := flags.Set(key, value); err != nil {
+			return fmt.Errorf("配置文件 %s: %s: %w", path, key, err)
+		}
+	}
+	return nil
+}
+
+// connFlagNames 返回配置文件中允许出现的 key，即全部连接参数的 flag 名
+func connFlagNames() map[string]bool {
+	flags := flag.NewFlagSet("", flag.ContinueOnError)
+	bindConnFlags(flags)
+
+	names := make(map[string]bool)
+	flags.VisitAll(func(f *flag.Flag) {
+		if f.Name != "config" {
+			names[f.Name] = true
+		}
+	})
+	return names
+}
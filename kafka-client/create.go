@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/IBM/sarama"
+
+	"kafka-topicctl/topicctl"
+)
+
+// configFlags 是可重复的 --topic-config key=value 参数
+type configFlags map[string]string
+
+// String 实现 flag.Value
+func (c configFlags) String() string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		pairs = append(pairs, k+"="+c[k])
+	}
+	return strings.Join(pairs, ",")
+}
+
+// Set 实现 flag.Value，解析一个 key=value
+func (c configFlags) Set(s string) error {
+	k, v, ok := strings.Cut(s, "=")
+	if !ok || strings.TrimSpace(k) == "" {
+		return fmt.Errorf("配置项 %q 格式应为 key=value", s)
+	}
+	c[strings.TrimSpace(k)] = v
+	return nil
+}
+
+// expandNames 展开 topic 名称模板；count 为 0 时只返回 topic 本身，否则把 {i} 替换为 0..count-1
+func expandNames(topic, template string, count int) ([]string, error) {
+	if template == "" {
+		template = topic
+	}
+	if count <= 0 {
+		if strings.Contains(template, "{i}") {
+			return nil, fmt.Errorf("名称模板 %q 含 {i}，需要同时指定 --count", template)
+		}
+		return []string{template}, nil
+	}
+	if !strings.Contains(template, "{i}") {
+		template += "-{i}"
+	}
+
+	names := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		names = append(names, strings.ReplaceAll(template, "{i}", strconv.Itoa(i)))
+	}
+	return names, nil
+}
+
+// createTopics 用相同的分区数、副本数和配置创建一组 topic，失败时继续处理剩余 topic；ctx 取消后停止
+func createTopics(ctx context.Context, conn *Config, names []string, partitions int32, rf int16, configs map[string]string) (int, error) {
+	file := &topicctl.ExportFile{}
+	for _, name := range names {
+		file.Topics = append(file.Topics, topicctl.Topic{
+			Name: name, Partitions: partitions, ReplicationFactor: rf, Configs: configs,
+		})
+	}
+	if problems := topicctl.Validate(file); len(problems) > 0 {
+		return 0, fmt.Errorf("参数校验失败:\n  %s", strings.Join(problems, "\n  "))
+	}
+
+	admin, err := newAdmin(conn)
+	if err != nil {
+		return 0, err
+	}
+	defer admin.Close()
+
+	entries := make(map[string]*string, len(configs))
+	for k, v := range configs {
+		vCopy := v
+		entries[k] = &vCopy
+	}
+	detail := &sarama.TopicDetail{
+		NumPartitions:     partitions,
+		ReplicationFactor: rf,
+		ConfigEntries:     entries,
+	}
+
+	var failed []string
+	for i, name := range names {
+		if err := ctx.Err(); err != nil {
+			return i - len(failed), fmt.Errorf("创建已中断: %d/%d 个 topic 已处理: %w", i, len(names), err)
+		}
+		if err := admin.CreateTopic(name, detail, false); err != nil {
+			fmt.Fprintf(os.Stderr, "❌ 创建 topic 失败: %s: %v\n", name, err)
+			failed = append(failed, name)
+			continue
+		}
+		fmt.Printf("✅ 创建 topic: %s\n", name)
+	}
+
+	created := len(names) - len(failed)
+	if len(failed) > 0 {
+		return created, fmt.Errorf("%d 个 topic 创建失败: %s", len(failed), strings.Join(failed, ", "))
+	}
+	return created, nil
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// confirm 列出将要删除的对象（kind 如 topic、ACL），并要求用户在 stdin 输入 yes 确认
+func confirm(kind string, names []string) bool {
+	fmt.Printf("将删除以下 %s:\n", kind)
+	for _, name := range names {
+		fmt.Printf("  - %s\n", name)
+	}
+	fmt.Print("输入 yes 确认删除: ")
+
+	line, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	return strings.TrimSpace(line) == "yes"
+}
+
+// bindAllowInternal 为带破坏性操作的子命令注册 --allow-internal
+func bindAllowInternal(fs *flag.FlagSet, c *Config) {
+	fs.BoolVar(&c.AllowInternal, "allow-internal", false, "允许操作以 _ 开头的内部 topic（如 __consumer_offsets、_schemas）")
+}
+
+// checkDeletable 在未指定 --allow-internal 时拒绝以 _ 开头的 topic；所有破坏性操作都应先经过它
+func checkDeletable(conn *Config, names []string) error {
+	if conn.AllowInternal {
+		return nil
+	}
+	var internal []string
+	for _, name := range names {
+		if strings.HasPrefix(name, "_") {
+			internal = append(internal, name)
+		}
+	}
+	if len(internal) > 0 {
+		return fmt.Errorf("拒绝操作内部 topic: %s（如确有需要请加 --allow-internal）", strings.Join(internal, ", "))
+	}
+	return nil
+}
+
+// deleteTopics 逐个删除 topic，失败时继续处理剩余 topic；包含内部 topic 时整体拒绝
+func deleteTopics(conn *Config, names []string) (int, error) {
+	if err := checkDeletable(conn, names); err != nil {
+		return 0, err
+	}
+
+	admin, err := newAdmin(conn)
+	if err != nil {
+		return 0, err
+	}
+	defer admin.Close()
+
+	var failed []string
+	for _, name := range names {
+		if err := admin.DeleteTopic(name); err != nil {
+			fmt.Fprintf(os.Stderr, "❌ 删除 topic 失败: %s: %v\n", name, err)
+			failed = append(failed, name)
+			continue
+		}
+		fmt.Printf("🗑️  删除 topic: %s\n", name)
+	}
+
+	deleted := len(names) - len(failed)
+	if len(failed) > 0 {
+		return deleted, fmt.Errorf("%d 个 topic 删除失败: %s", len(failed), strings.Join(failed, ", "))
+	}
+	return deleted, nil
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/IBM/sarama"
+
+	"kafka-topicctl/topicctl"
+)
+
+// describeTopic 打印单个 topic 的分区、副本和配置详情；tmpl 非 nil 时按模板输出，
+// 模板中的 Configs 为全部生效配置（敏感配置不显示值），PartitionDetail 为各分区的 leader / 副本 / ISR
+func describeTopic(conn *Config, name string, tmpl *topicTemplate) error {
+	admin, err := newAdmin(conn)
+	if err != nil {
+		return err
+	}
+	defer admin.Close()
+
+	metas, err := admin.DescribeTopics([]string{name})
+	if err != nil {
+		return err
+	}
+	if len(metas) == 0 || errors.Is(metas[0].Err, sarama.ErrUnknownTopicOrPartition) {
+		return fmt.Errorf("topic 不存在: %s", name)
+	}
+	meta := metas[0]
+	if meta.Err != sarama.ErrNoError {
+		return fmt.Errorf("describe topic %s: %w", name, meta.Err)
+	}
+
+	entries, err := admin.DescribeConfig(sarama.ConfigResource{
+		Type: sarama.TopicResource,
+		Name: name,
+	})
+	if err != nil {
+		return err
+	}
+
+	partitions := meta.Partitions
+	sort.Slice(partitions, func(i, j int) bool {
+		return partitions[i].ID < partitions[j].ID
+	})
+
+	replicationFactor := 0
+	if len(partitions) > 0 {
+		replicationFactor = len(partitions[0].Replicas)
+	}
+
+	if tmpl != nil {
+		t := topicctl.Topic{
+			Name:              name,
+			Partitions:        int32(len(partitions)),
+			ReplicationFactor: int16(replicationFactor),
+			Configs:           make(map[string]string, len(entries)),
+		}
+		for _, e := range entries {
+			t.Configs[e.Name] = e.Value
+			if e.Sensitive {
+				t.Configs[e.Name] = "<sensitive>"
+			}
+		}
+		for _, p := range partitions {
+			t.PartitionDetail = append(t.PartitionDetail, topicctl.PartitionDetail{ID: p.ID, Leader: p.Leader, Replicas: p.Replicas, ISR: p.Isr})
+		}
+		return tmpl.render(os.Stdout, []topicctl.Topic{t})
+	}
+
+	fmt.Printf("Topic:              %s\n", name)
+	fmt.Printf("Partitions:         %d\n", len(partitions))
+	fmt.Printf("Replication factor: %d\n", replicationFactor)
+
+	fmt.Println()
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "PARTITION\tLEADER\tREPLICAS\tISR")
+	for _, p := range partitions {
+		fmt.Fprintf(w, "%d\t%d\t%v\t%v\n", p.ID, p.Leader, p.Replicas, p.Isr)
+	}
+	w.Flush()
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name < entries[j].Name
+	})
+
+	fmt.Println()
+	w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "CONFIG\tVALUE\tSOURCE")
+	for _, e := range entries {
+		value := e.Value
+		if e.Sensitive {
+			value = "<sensitive>"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", e.Name, value, e.Source)
+	}
+	return w.Flush()
+}
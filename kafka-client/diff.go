@@ -0,0 +1,118 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"kafka-topicctl/topicctl"
+)
+
+// printDiff 按类别输出对比结果
+func printDiff(d *topicctl.Diff) {
+	if len(d.OnlyInFile) > 0 {
+		fmt.Printf("📄 仅存在于文件 (%d):\n", len(d.OnlyInFile))
+		for _, name := range d.OnlyInFile {
+			fmt.Printf("  + %s\n", name)
+		}
+	}
+	if len(d.OnlyInCluster) > 0 {
+		fmt.Printf("🖥️  仅存在于集群 (%d):\n", len(d.OnlyInCluster))
+		for _, name := range d.OnlyInCluster {
+			fmt.Printf("  - %s\n", name)
+		}
+	}
+	if len(d.Changed) > 0 {
+		fmt.Printf("✏️  存在差异 (%d)，格式为 集群值 -> 文件值:\n", len(d.Changed))
+		for _, c := range d.Changed {
+			fmt.Printf("  ~ %s\n", c.Name)
+			for _, f := range c.Changes {
+				fmt.Printf("      %s: %s -> %s\n", f.Field, f.Old, f.New)
+			}
+		}
+	}
+}
+
+// writeDiffJSON 以 JSON 输出对比结果，空类别输出 [] 而不是 null
+func writeDiffJSON(w io.Writer, d *topicctl.Diff) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(jsonDiff(d))
+}
+
+// jsonDiff 返回把空类别替换为空切片后的副本，用于 JSON 输出
+func jsonDiff(d *topicctl.Diff) topicctl.Diff {
+	out := *d
+	if out.OnlyInFile == nil {
+		out.OnlyInFile = []string{}
+	}
+	if out.OnlyInCluster == nil {
+		out.OnlyInCluster = []string{}
+	}
+	if out.Changed == nil {
+		out.Changed = []topicctl.TopicChange{}
+	}
+	return out
+}
+
+// diffCluster 读取文件并与集群当前状态对比
+func diffCluster(conn *Config, in, format string, excludeInternal bool) (*topicctl.Diff, error) {
+	file, err := topicctl.LoadFile(in, format)
+	if err != nil {
+		return nil, err
+	}
+
+	admin, err := newAdmin(conn)
+	if err != nil {
+		return nil, err
+	}
+	defer admin.Close()
+
+	have, err := topicctl.ListTopics(admin, &topicctl.Filter{ExcludeInternal: excludeInternal})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := fillPartitionDetail(admin, file, have); err != nil {
+		return nil, err
+	}
+
+	want := append([]topicctl.Topic(nil), file.Topics...)
+	sort.Slice(want, func(i, j int) bool {
+		return want[i].Name < want[j].Name
+	})
+
+	return topicctl.DiffTopics(want, have), nil
+}
+
+// fillPartitionDetail 只为文件中记录了分区元数据的 topic 查询集群当前的 leader / 副本 / ISR
+func fillPartitionDetail(admin topicctl.Admin, file *topicctl.ExportFile, have []topicctl.Topic) error {
+	detailed := make(map[string]bool)
+	for _, t := range file.Topics {
+		if len(t.PartitionDetail) > 0 {
+			detailed[t.Name] = true
+		}
+	}
+	if len(detailed) == 0 {
+		return nil
+	}
+
+	var sub []topicctl.Topic
+	for _, h := range have {
+		if detailed[h.Name] {
+			sub = append(sub, h)
+		}
+	}
+	if err := topicctl.FillPartitionDetail(admin, sub); err != nil {
+		return err
+	}
+	details := make(map[string][]topicctl.PartitionDetail, len(sub))
+	for _, t := range sub {
+		details[t.Name] = t.PartitionDetail
+	}
+	for i := range have {
+		have[i].PartitionDetail = details[have[i].Name]
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/IBM/sarama"
+
+	"kafka-topicctl/topicctl"
+)
+
+// electionTypes 是 --type 支持的 leader 选举类型
+var electionTypes = map[string]sarama.ElectionType{
+	"preferred": sarama.PreferredElection,
+	"unclean":   sarama.UncleanElection,
+}
+
+// electionSummary 汇总一次 leader 选举的结果
+type electionSummary struct {
+	Changed, Optimal, Failed int
+}
+
+// electLeaders 对 topics（为空时为通过过滤的全部 topic）的全部分区触发 leader 选举，
+// 逐个输出 leader 发生变化和选举失败的分区；已是最优（ELECTION_NOT_NEEDED）的分区只计数
+func electLeaders(conn *Config, topics []string, filter *topicctl.Filter, typ sarama.ElectionType) (electionSummary, error) {
+	var sum electionSummary
+
+	admin, err := newAdmin(conn)
+	if err != nil {
+		return sum, err
+	}
+	defer admin.Close()
+
+	if len(topics) == 0 {
+		all, err := topicctl.ListTopics(admin, filter)
+		if err != nil {
+			return sum, err
+		}
+		for _, t := range all {
+			topics = append(topics, t.Name)
+		}
+	}
+	if len(topics) == 0 {
+		fmt.Println("ℹ️  没有需要选举的 topic")
+		return sum, nil
+	}
+
+	before, err := partitionLeaders(admin, topics)
+	if err != nil {
+		return sum, err
+	}
+	partitions := make(map[string][]int32, len(before))
+	for topic, leaders := range before {
+		for p := range leaders {
+			partitions[topic] = append(partitions[topic], p)
+		}
+	}
+
+	results, err := admin.ElectLeaders(typ, partitions)
+	if err != nil {
+		return sum, fmt.Errorf("leader 选举失败: %w", err)
+	}
+
+	var changedTopics []string
+	for topic, byPartition := range results {
+		for _, r := range byPartition {
+			if r.ErrorCode == sarama.ErrNoError {
+				changedTopics = append(changedTopics, topic)
+				break
+			}
+		}
+	}
+	after := map[string]map[int32]int32{}
+	if len(changedTopics) > 0 {
+		if after, err = partitionLeaders(admin, changedTopics); err != nil {
+			return sum, err
+		}
+	}
+
+	names := make([]string, 0, len(results))
+	for topic := range results {
+		names = append(names, topic)
+	}
+	sort.Strings(names)
+	for _, topic := range names {
+		ids := make([]int32, 0, len(results[topic]))
+		for p := range results[topic] {
+			ids = append(ids, p)
+		}
+		sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+		for _, p := range ids {
+			r := results[topic][p]
+			switch {
+			case r.ErrorCode == sarama.ErrNoError:
+				sum.Changed++
+				fmt.Printf("🔁 %s-%d: leader %d -> %d\n", topic, p, before[topic][p], after[topic][p])
+			case errors.Is(r.ErrorCode, sarama.ErrElectionNotNeeded):
+				sum.Optimal++
+			default:
+				sum.Failed++
+				msg := r.ErrorCode.Error()
+				if r.ErrorMessage != nil && *r.ErrorMessage != "" {
+					msg = *r.ErrorMessage
+				}
+				fmt.Printf("❌ %s-%d: %s\n", topic, p, msg)
+			}
+		}
+	}
+
+	if sum.Failed > 0 {
+		return sum, fmt.Errorf("%d 个分区 leader 选举失败", sum.Failed)
+	}
+	return sum, nil
+}
+
+// partitionLeaders 查询 topic 每个分区当前的 leader
+func partitionLeaders(admin sarama.ClusterAdmin, topics []string) (map[string]map[int32]int32, error) {
+	metas, err := admin.DescribeTopics(topics)
+	if err != nil {
+		return nil, fmt.Errorf("查询分区元数据失败: %w", err)
+	}
+	leaders := make(map[string]map[int32]int32, len(metas))
+	for _, m := range metas {
+		if m.Err != sarama.ErrNoError {
+			return nil, fmt.Errorf("查询 topic %s 的分区元数据失败: %w", m.Name, m.Err)
+		}
+		leaders[m.Name] = make(map[int32]int32, len(m.Partitions))
+		for _, p := range m.Partitions {
+			leaders[m.Name][p.ID] = p.Leader
+		}
+	}
+	return leaders, nil
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"os"
+
+	"github.com/IBM/sarama"
+)
+
+// 退出码约定，所有子命令一致，并在用法说明中列出。进程只通过 exit 结束
+const (
+	exitOK         = 0 // 成功
+	exitError      = 1 // 运行时错误，或用户在确认提示中取消
+	exitUsage      = 2 // 用法错误：未知子命令、参数缺失、非法或互相冲突
+	exitPartial    = 3 // 部分失败：部分 topic 处理失败，其余已成功
+	exitConnection = 4 // 无法连接集群
+
+	// exitChanged 表示命令成功且结果非空: import 创建或修改了 topic、diff / compare 发现差异、health 发现副本不足的分区
+	exitChanged = 10
+)
+
+// exitCodesHelp 是用法说明中的退出码说明
+const exitCodesHelp = "退出码: 0 成功 / 1 运行时错误 / 2 用法错误 / 3 部分失败 / 4 无法连接集群 / 10 有变更或差异"
+
+// exit 是结束进程的唯一出口
+func exit(code int) {
+	os.Exit(code)
+}
+
+// usage 打印子命令的参数说明并以 exitUsage 退出
+func usage(fs *flag.FlagSet) {
+	fs.Usage()
+	exit(exitUsage)
+}
+
+// usageError 表示参数组合非法，fatal 时以 exitUsage 退出
+type usageError string
+
+// Error 返回错误描述
+func (e usageError) Error() string {
+	return string(e)
+}
+
+// partialError 表示部分 topic 处理失败而其余已成功，fatal 时以 exitPartial 退出
+type partialError struct {
+	err error
+}
+
+// Error 返回被包装错误的描述
+func (e *partialError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap 支持 errors.Is / errors.As
+func (e *partialError) Unwrap() error {
+	return e.err
+}
+
+// partial 在 err 非 nil 且有 topic 已成功处理时把 err 标记为部分失败
+func partial(err error, succeeded int) error {
+	if err == nil || succeeded == 0 {
+		return err
+	}
+	return &partialError{err: err}
+}
+
+// exitCode 按错误类型确定退出码
+func exitCode(err error) int {
+	var ue usageError
+	var pe *partialError
+	var ce *connectError
+	switch {
+	case errors.As(err, &ue):
+		return exitUsage
+	case errors.As(err, &ce), errors.Is(err, sarama.ErrOutOfBrokers):
+		return exitConnection
+	case errors.As(err, &pe):
+		return exitPartial
+	}
+	return exitError
+}
@@ -0,0 +1,340 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/IBM/sarama"
+)
+
+// listGroupNames 返回按名称排序的 consumer group；指定 group 时只返回该 group
+func listGroupNames(admin sarama.ClusterAdmin, group string) ([]string, error) {
+	groups, err := admin.ListConsumerGroups()
+	if err != nil {
+		return nil, err
+	}
+
+	if group != "" {
+		if _, ok := groups[group]; !ok {
+			return nil, fmt.Errorf("consumer group 不存在: %s", group)
+		}
+		return []string{group}, nil
+	}
+
+	names := make([]string, 0, len(groups))
+	for name := range groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// assignedTopics 汇总 group 成员分配到的 topic
+func assignedTopics(desc *sarama.GroupDescription) []string {
+	set := make(map[string]struct{})
+	for _, m := range desc.Members {
+		assignment, err := m.GetMemberAssignment()
+		if err != nil || assignment == nil {
+			continue
+		}
+		for topic := range assignment.Topics {
+			set[topic] = struct{}{}
+		}
+	}
+
+	topics := make([]string, 0, len(set))
+	for t := range set {
+		topics = append(topics, t)
+	}
+	sort.Strings(topics)
+	return topics
+}
+
+// listGroups 以表格形式打印 consumer group，describe 时展开状态、成员数和分配的 topic
+func listGroups(conn *Config, group string, describe bool) error {
+	admin, err := newAdmin(conn)
+	if err != nil {
+		return err
+	}
+	defer admin.Close()
+
+	names, err := listGroupNames(admin, group)
+	if err != nil {
+		return err
+	}
+	if len(names) == 0 {
+		fmt.Println("集群中没有 consumer group")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	if !describe {
+		fmt.Fprintln(w, "GROUP")
+		for _, name := range names {
+			fmt.Fprintln(w, name)
+		}
+		return w.Flush()
+	}
+
+	descs, err := admin.DescribeConsumerGroups(names)
+	if err != nil {
+		return err
+	}
+	sort.Slice(descs, func(i, j int) bool {
+		return descs[i].GroupId < descs[j].GroupId
+	})
+
+	fmt.Fprintln(w, "GROUP\tSTATE\tMEMBERS\tTOPICS")
+	for _, d := range descs {
+		topics := strings.Join(assignedTopics(d), ",")
+		if topics == "" {
+			topics = "-"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%d\t%s\n", d.GroupId, d.State, len(d.Members), topics)
+	}
+	return w.Flush()
+}
+
+// partitionOffset 是 group 在一个分区上的已提交 offset
+type partitionOffset struct {
+	Topic     string
+	Partition int32
+	Offset    int64
+}
+
+// committedOffsets 返回 group 已提交的 offset，按 topic、分区排序；topic 非空时只返回该 topic
+func committedOffsets(admin sarama.ClusterAdmin, group, topic string) ([]partitionOffset, error) {
+	resp, err := admin.ListConsumerGroupOffsets(group, nil)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Err != sarama.ErrNoError {
+		return nil, fmt.Errorf("查询 group %s 的 offset 失败: %w", group, resp.Err)
+	}
+
+	var offsets []partitionOffset
+	for t, partitions := range resp.Blocks {
+		if topic != "" && t != topic {
+			continue
+		}
+		for p, block := range partitions {
+			if block.Err != sarama.ErrNoError || block.Offset < 0 {
+				continue
+			}
+			offsets = append(offsets, partitionOffset{Topic: t, Partition: p, Offset: block.Offset})
+		}
+	}
+
+	sort.Slice(offsets, func(i, j int) bool {
+		if offsets[i].Topic != offsets[j].Topic {
+			return offsets[i].Topic < offsets[j].Topic
+		}
+		return offsets[i].Partition < offsets[j].Partition
+	})
+	return offsets, nil
+}
+
+// showGroupOffsets 打印 group 每个分区的已提交 offset、log end offset 和 lag
+func showGroupOffsets(conn *Config, group string) error {
+	admin, err := newAdmin(conn)
+	if err != nil {
+		return err
+	}
+	defer admin.Close()
+
+	client, err := newClient(conn)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	names, err := listGroupNames(admin, group)
+	if err != nil {
+		return err
+	}
+	if len(names) == 0 {
+		fmt.Println("集群中没有 consumer group")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "GROUP\tTOPIC\tPARTITION\tOFFSET\tLOG-END\tLAG")
+	for _, name := range names {
+		offsets, err := committedOffsets(admin, name, "")
+		if err != nil {
+			return err
+		}
+		for _, o := range offsets {
+			end, err := client.GetOffset(o.Topic, o.Partition, sarama.OffsetNewest)
+			if err != nil {
+				return fmt.Errorf("查询 %s-%d 的 log end offset 失败: %w", o.Topic, o.Partition, err)
+			}
+			fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%d\t%d\n", name, o.Topic, o.Partition, o.Offset, end, end-o.Offset)
+		}
+	}
+	return w.Flush()
+}
+
+// resetTarget 描述 offset 重置的目标：earliest、latest 或固定 offset
+type resetTarget struct {
+	Earliest bool
+	Latest   bool
+	Offset   int64 // Earliest/Latest 都为 false 时使用
+}
+
+// ensureGroupInactive 在 group 仍有活跃成员时拒绝继续，避免覆盖正在运行的消费者
+func ensureGroupInactive(admin sarama.ClusterAdmin, group string) error {
+	descs, err := admin.DescribeConsumerGroups([]string{group})
+	if err != nil {
+		return err
+	}
+	for _, d := range descs {
+		if len(d.Members) > 0 {
+			return fmt.Errorf("group %s 仍有 %d 个活跃成员（状态 %s），请先停止消费者或使用 --force", group, len(d.Members), d.State)
+		}
+	}
+	return nil
+}
+
+// resetGroupOffsets 把 group 已提交的 offset 重置到目标位置；topic 非空时只重置该 topic
+func resetGroupOffsets(conn *Config, group, topic string, target resetTarget, force bool) error {
+	admin, err := newAdmin(conn)
+	if err != nil {
+		return err
+	}
+	defer admin.Close()
+
+	if !force {
+		if err := ensureGroupInactive(admin, group); err != nil {
+			return err
+		}
+	}
+
+	offsets, err := committedOffsets(admin, group, topic)
+	if err != nil {
+		return err
+	}
+	if len(offsets) == 0 {
+		return fmt.Errorf("group %s 没有可重置的已提交 offset", group)
+	}
+
+	client, err := newClient(conn)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	next := make([]partitionOffset, len(offsets))
+	for i, o := range offsets {
+		next[i] = partitionOffset{Topic: o.Topic, Partition: o.Partition, Offset: target.Offset}
+		switch {
+		case target.Earliest:
+			next[i].Offset, err = client.GetOffset(o.Topic, o.Partition, sarama.OffsetOldest)
+		case target.Latest:
+			next[i].Offset, err = client.GetOffset(o.Topic, o.Partition, sarama.OffsetNewest)
+		}
+		if err != nil {
+			return fmt.Errorf("查询 %s-%d 的 offset 失败: %w", o.Topic, o.Partition, err)
+		}
+	}
+
+	committed, err := commitGroupOffsets(client, group, next)
+	type partition struct {
+		topic string
+		id    int32
+	}
+	old := make(map[partition]int64, len(offsets))
+	for _, o := range offsets {
+		old[partition{o.Topic, o.Partition}] = o.Offset
+	}
+	for _, o := range committed {
+		fmt.Printf("↩️  %s %s-%d: %d -> %d\n", group, o.Topic, o.Partition, old[partition{o.Topic, o.Partition}], o.Offset)
+	}
+	return partial(err, len(committed))
+}
+
+// commitGroupOffsets 直接向 group coordinator 发送 OffsetCommitRequest，把 offsets 设为 group 的已提交 offset（可前移也可后移），
+// 并检查每个分区的返回结果；OffsetManager.Commit 不返回错误，提交失败时也无从得知，因此不使用它。
+// coordinator 已迁移时刷新后重试一次。返回提交成功的分区，有分区失败时同时返回错误
+func commitGroupOffsets(client sarama.Client, group string, offsets []partitionOffset) ([]partitionOffset, error) {
+	req, timestamp := newOffsetCommitRequest(client.Config().Version, group)
+	for _, o := range offsets {
+		req.AddBlockWithLeaderEpoch(o.Topic, o.Partition, o.Offset, -1, timestamp, "")
+	}
+
+	send := func() (*sarama.OffsetCommitResponse, error) {
+		coordinator, err := client.Coordinator(group)
+		if err != nil {
+			return nil, fmt.Errorf("查询 group %s 的 coordinator 失败: %w", group, err)
+		}
+		return coordinator.CommitOffset(req)
+	}
+	rsp, err := send()
+	if err == nil && coordinatorMoved(rsp) {
+		if err := client.RefreshCoordinator(group); err != nil {
+			return nil, fmt.Errorf("刷新 group %s 的 coordinator 失败: %w", group, err)
+		}
+		rsp, err = send()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("提交 group %s 的 offset 失败: %w", group, err)
+	}
+
+	var committed []partitionOffset
+	var errs []error
+	for _, o := range offsets {
+		kerr, ok := rsp.Errors[o.Topic][o.Partition]
+		switch {
+		case !ok:
+			errs = append(errs, fmt.Errorf("%s-%d: %w", o.Topic, o.Partition, sarama.ErrIncompleteResponse))
+		case kerr != sarama.ErrNoError:
+			errs = append(errs, fmt.Errorf("%s-%d: %w", o.Topic, o.Partition, kerr))
+		default:
+			committed = append(committed, o)
+		}
+	}
+	if len(errs) > 0 {
+		return committed, fmt.Errorf("group %s 有 %d 个分区提交 offset 失败:\n%w", group, len(errs), errors.Join(errs...))
+	}
+	return committed, nil
+}
+
+// newOffsetCommitRequest 按协议版本构建不属于任何 generation 的 OffsetCommitRequest，返回请求和每个分区应填的提交时间戳；
+// 版本选择与 sarama 的 OffsetManager 一致，group 仍有活跃成员时 broker 会拒绝提交
+func newOffsetCommitRequest(version sarama.KafkaVersion, group string) (*sarama.OffsetCommitRequest, int64) {
+	req := &sarama.OffsetCommitRequest{
+		Version:                 1,
+		ConsumerGroup:           group,
+		ConsumerGroupGeneration: sarama.GroupGenerationUndefined,
+	}
+	switch {
+	case version.IsAtLeast(sarama.V2_1_0_0):
+		req.Version = 6 // v5 起保留时间只由 broker 决定
+	case version.IsAtLeast(sarama.V2_0_0_0):
+		req.Version, req.RetentionTime = 4, -1
+	case version.IsAtLeast(sarama.V0_11_0_0):
+		req.Version, req.RetentionTime = 3, -1
+	case version.IsAtLeast(sarama.V0_9_0_0):
+		req.Version, req.RetentionTime = 2, -1
+	default:
+		// 只有 v1 带提交时间戳，ReceiveTime 表示由 broker 取收到请求的时间
+		return req, sarama.ReceiveTime
+	}
+	return req, 0
+}
+
+// coordinatorMoved 判断提交是否因 coordinator 迁移或暂不可用而失败，这种情况刷新 coordinator 后可以重试
+func coordinatorMoved(rsp *sarama.OffsetCommitResponse) bool {
+	for _, partitions := range rsp.Errors {
+		for _, kerr := range partitions {
+			if kerr == sarama.ErrNotCoordinatorForConsumer || kerr == sarama.ErrConsumerCoordinatorNotAvailable {
+				return true
+			}
+		}
+	}
+	return false
+}
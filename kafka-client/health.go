@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/IBM/sarama"
+
+	"kafka-topicctl/topicctl"
+)
+
+// checkHealth 统计每个 topic 中 ISR 数少于副本数的分区（under-replicated），
+// 只打印有问题的 topic，返回 under-replicated 分区总数
+func checkHealth(conn *Config, filter *topicctl.Filter) (int, error) {
+	admin, err := newAdmin(conn)
+	if err != nil {
+		return 0, err
+	}
+	defer admin.Close()
+
+	topics, err := topicctl.ListTopics(admin, filter)
+	if err != nil {
+		return 0, err
+	}
+	names := make([]string, len(topics))
+	for i, t := range topics {
+		names[i] = t.Name
+	}
+	// 空列表会被 sarama 编码为查询全部 topic 的元数据请求，绕过过滤
+	if len(names) == 0 {
+		fmt.Println("🎉 没有匹配的 topic")
+		return 0, nil
+	}
+
+	metas, err := admin.DescribeTopics(names)
+	if err != nil {
+		return 0, err
+	}
+	sort.Slice(metas, func(i, j int) bool { return metas[i].Name < metas[j].Name })
+	for _, meta := range metas {
+		if meta.Err != sarama.ErrNoError {
+			return 0, fmt.Errorf("查询 topic %s 的元数据失败: %w", meta.Name, meta.Err)
+		}
+	}
+
+	total := 0
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TOPIC\tUNDER-REPLICATED\tPARTITIONS")
+	for _, meta := range metas {
+		under := 0
+		for _, p := range meta.Partitions {
+			if len(p.Isr) < len(p.Replicas) {
+				under++
+			}
+		}
+		if under > 0 {
+			fmt.Fprintf(w, "%s\t%d\t%d\n", meta.Name, under, len(meta.Partitions))
+			total += under
+		}
+	}
+	if total == 0 {
+		fmt.Printf("🎉 %d 个 topic 均无 under-replicated 分区\n", len(metas))
+		return 0, nil
+	}
+	if err := w.Flush(); err != nil {
+		return total, err
+	}
+	fmt.Printf("\n❌ 共 %d 个 under-replicated 分区\n", total)
+	return total, nil
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"kafka-topicctl/topicctl"
+)
+
+// importSource 描述导入文件以及导入前对文件做的检查和预处理
+type importSource struct {
+	In, Format string
+	Strict     bool   // 先做 JSON Schema 校验
+	BaseConfig string // 非空时把该文件中的公共配置合并到每个 topic 之下
+	AllowUnset bool   // ${VAR} 未设置时替换为空串而不是报错
+	WarnOnly   bool   // min.insync.replicas 检查只告警不中止
+
+	AllowUnspecified bool // schema 校验时允许省略 partitions / replication_factor
+
+	ConfigValidate bool // 按 broker 认识的配置项检查文件中的配置 key，Strict 时未知 key 视为错误
+	ExpandAliases  bool // 把 retention=7d 等简写别名展开为 Kafka 配置项
+}
+
+// importTopics 从 JSON/YAML 文件导入 topic；依次做 schema 校验、合并公共配置、替换 ${VAR}、展开配置别名和配置 key 检查，
+// min.insync.replicas 在 ImportTopics 覆盖副本数之后按最终副本数检查
+func importTopics(ctx context.Context, conn *Config, src importSource, opts topicctl.ImportOptions) (topicctl.Result, error) {
+	if src.Strict {
+		if err := checkSchema(src.In, src.Format, topicctl.ValidateOptions{AllowUnspecified: src.AllowUnspecified}); err != nil {
+			return topicctl.Result{}, err
+		}
+	}
+
+	file, err := topicctl.LoadFile(src.In, src.Format)
+	if err != nil {
+		return topicctl.Result{}, err
+	}
+	if src.BaseConfig != "" {
+		base, err := topicctl.LoadBaseConfig(src.BaseConfig, "auto")
+		if err != nil {
+			return topicctl.Result{}, err
+		}
+		// 每个 topic 继承了哪些公共配置只在 --debug 时输出
+		var debug func(topicctl.Event)
+		if conn.Debug {
+			debug = lg.log
+		}
+		topicctl.MergeBaseConfig(file, base, debug)
+	}
+	if err := topicctl.ExpandEnv(file, src.AllowUnset); err != nil {
+		return topicctl.Result{}, err
+	}
+	if src.ExpandAliases {
+		if err := topicctl.ExpandAliases(file); err != nil {
+			return topicctl.Result{}, err
+		}
+	}
+	admin, err := newAdmin(conn)
+	if err != nil {
+		return topicctl.Result{}, err
+	}
+	defer admin.Close()
+
+	if src.ConfigValidate {
+		if err := checkConfigKeys(admin, file, src); err != nil {
+			return topicctl.Result{}, err
+		}
+	}
+
+	// 在 --replication-factor / --max-replication-factor 生效之后按最终副本数检查 min.insync.replicas
+	opts.Preflight = func(file *topicctl.ExportFile) error {
+		problems := topicctl.CheckMinISR(file)
+		if len(problems) == 0 {
+			return nil
+		}
+		if !src.WarnOnly {
+			return fmt.Errorf("%s 未通过 min.insync.replicas 检查:\n  %s", src.In, strings.Join(problems, "\n  "))
+		}
+		for _, p := range problems {
+			lg.log(topicctl.Event{Action: "validate", Status: "warning", Detail: p, Message: "⚠️  " + p})
+		}
+		return nil
+	}
+	opts.Log = lg.log
+	if !lg.quiet && !lg.json {
+		opts.Progress = newProgress().report
+	}
+	return topicctl.ImportTopics(ctx, admin, file, opts)
+}
+
+// checkConfigKeys 检查文件中的配置 key 是否为 broker 认识的 topic 配置项；默认只告警，Strict 时返回错误
+func checkConfigKeys(admin topicctl.Admin, file *topicctl.ExportFile, src importSource) error {
+	known, err := topicctl.KnownTopicConfigs(admin)
+	if err != nil {
+		return err
+	}
+	problems := topicctl.CheckConfigKeys(file, known)
+	if len(problems) == 0 {
+		return nil
+	}
+	if src.Strict {
+		return fmt.Errorf("%s 包含 broker 不认识的配置项:\n  %s", src.In, strings.Join(problems, "\n  "))
+	}
+	for _, p := range problems {
+		lg.log(topicctl.Event{Action: "validate", Status: "warning", Detail: p, Message: "⚠️  " + p})
+	}
+	return nil
+}
+
+// checkSchema 用内嵌 JSON Schema 校验文件，有问题时把全部问题合并为一个错误
+func checkSchema(in, format string, opts topicctl.ValidateOptions) error {
+	problems, err := topicctl.CheckSchemaWith(in, format, opts)
+	if err != nil {
+		return err
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("%s 未通过 schema 校验:\n  %s", in, strings.Join(problems, "\n  "))
+	}
+	return nil
+}
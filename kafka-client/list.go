@@ -0,0 +1,69 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"kafka-topicctl/topicctl"
+)
+
+// sortTopics 按指定字段排序；字段相同时按名称排序
+func sortTopics(topics []topicctl.Topic, by string) error {
+	var less func(a, b topicctl.Topic) bool
+	switch by {
+	case "", "name":
+		less = func(a, b topicctl.Topic) bool { return false }
+	case "partitions":
+		less = func(a, b topicctl.Topic) bool { return a.Partitions < b.Partitions }
+	case "replication-factor":
+		less = func(a, b topicctl.Topic) bool { return a.ReplicationFactor < b.ReplicationFactor }
+	default:
+		return fmt.Errorf("不支持的排序字段 %q（可选 name / partitions / replication-factor）", by)
+	}
+
+	// ListTopics 已按名称排序，稳定排序即可保持同值按名称排列
+	sort.SliceStable(topics, func(i, j int) bool {
+		return less(topics[i], topics[j])
+	})
+	return nil
+}
+
+// listTopics 以表格形式打印 topic 名称、分区数和副本数，tmpl 非 nil 时按模板输出；activeWithin 非 0 时只列出最近有写入的 topic
+func listTopics(conn *Config, filter *topicctl.Filter, sortBy string, activeWithin time.Duration, tmpl *topicTemplate) error {
+	admin, err := newAdmin(conn)
+	if err != nil {
+		return err
+	}
+	defer admin.Close()
+
+	topics, err := topicctl.ListTopics(admin, filter)
+	if err != nil {
+		return err
+	}
+	if activeWithin > 0 {
+		client, err := newClient(conn)
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+		if topics, err = topicctl.ActiveTopics(client, topics, time.Now().Add(-activeWithin)); err != nil {
+			return err
+		}
+	}
+	if err := sortTopics(topics, sortBy); err != nil {
+		return err
+	}
+	if tmpl != nil {
+		return tmpl.render(os.Stdout, topics)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tPARTITIONS\tREPLICATION FACTOR")
+	for _, t := range topics {
+		fmt.Fprintf(w, "%s\t%d\t%d\n", t.Name, t.Partitions, t.ReplicationFactor)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"kafka-topicctl/topicctl"
+)
+
+// logger 输出操作日志，默认人类可读，json 模式下每行一个 JSON 对象
+type logger struct {
+	json  bool
+	quiet bool // 不输出单个 topic 的处理结果，只保留汇总、警告和错误
+	bar   bool // stderr 上有未完成的进度条，输出日志前需要先清掉
+	out   io.Writer
+}
+
+// lg 是全局日志输出，由 bindLogFlags 注册的参数控制格式
+var lg = &logger{out: os.Stdout}
+
+// bindLogFlags 在子命令的 FlagSet 上注册 --log-format 和 --quiet
+func bindLogFlags(fs *flag.FlagSet) {
+	fs.BoolVar(&lg.quiet, "quiet", false, "不输出每个 topic 的处理结果，只打印汇总（警告和错误仍会输出）")
+	fs.Func("log-format", "日志格式: text / json（默认 text）", func(v string) error {
+		switch v {
+		case "text":
+			lg.json = false
+		case "json":
+			lg.json = true
+		default:
+			return fmt.Errorf("不支持的日志格式 %q（可选 text / json）", v)
+		}
+		return nil
+	})
+}
+
+// log 输出一条日志；人类可读格式下打印 e.Message
+func (l *logger) log(e topicctl.Event) {
+	if l.quiet && e.Topic != "" && e.Status != "warning" && e.Status != "error" {
+		return
+	}
+	l.write(l.out, e)
+}
+
+// logStderr 与 log 格式相同，但不管 out 指向哪里都写到 stderr，用于不能混进 stdout 数据流的诊断信息
+func (l *logger) logStderr(e topicctl.Event) {
+	l.write(os.Stderr, e)
+}
+
+// write 按当前格式把 e 写到 w
+func (l *logger) write(w io.Writer, e topicctl.Event) {
+	if l.bar {
+		fmt.Fprint(os.Stderr, "\r\033[K")
+		l.bar = false
+	}
+	if l.json {
+		data, _ := json.Marshal(e)
+		fmt.Fprintln(w, string(data))
+		return
+	}
+	fmt.Fprintln(w, e.Message)
+}
@@ -1,186 +1,1297 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"os"
+	"os/signal"
+	"runtime/debug"
 	"sort"
+	"strings"
+	"syscall"
 	"time"
 
-	"github.com/IBM/sarama"
+	"kafka-topicctl/topicctl"
 )
 
-// Topic 是导出/导入的 JSON 结构
-type Topic struct {
-	Name              string            `json:"name"`
-	Partitions        int32             `json:"partitions"`
-	ReplicationFactor int16             `json:"replication_factor"`
-	Configs           map[string]string `json:"configs,omitempty"`
+// splitList 把逗号分隔的列表拆分为切片，去掉空白和空项
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
 }
 
-// ExportFile 是整个导出文件的结构
-type ExportFile struct {
-	KafkaVersion string  `json:"kafka_version"`
-	ExportTime   string  `json:"export_time"`
-	Topics       []Topic `json:"topics"`
+// bindPrefixFlags 注册可重复的 --include-prefix / --exclude-prefix，按名称前缀筛选 topic，与 --include / --exclude 正则同时生效
+func bindPrefixFlags(fs *flag.FlagSet) (include, exclude *[]string) {
+	include, exclude = new([]string), new([]string)
+	fs.Func("include-prefix", "只保留以该前缀开头的 topic（可重复或用逗号分隔，满足任一即可）", func(v string) error {
+		*include = append(*include, splitList(v)...)
+		return nil
+	})
+	fs.Func("exclude-prefix", "排除以该前缀开头的 topic，如 tmp.、test-（可重复或用逗号分隔）", func(v string) error {
+		*exclude = append(*exclude, splitList(v)...)
+		return nil
+	})
+	return include, exclude
 }
 
-// newAdmin 创建 Sarama ClusterAdmin
-func newAdmin(broker string) (sarama.ClusterAdmin, error) {
-	cfg := sarama.NewConfig()
-	cfg.Version = sarama.V2_4_0_0
-	cfg.Admin.Timeout = 10 * time.Second
-	return sarama.NewClusterAdmin([]string{broker}, cfg)
-}
+// formatCategories 把各错误类别的数量格式化为 "类别=数量"，按类别名排序
+func formatCategories(counts map[string]int) string {
+	categories := make([]string, 0, len(counts))
+	for c := range counts {
+		categories = append(categories, c)
+	}
+	sort.Strings(categories)
 
-// exportTopics 导出 topic 到 JSON 文件
-func exportTopics(broker, out string, excludeInternal bool) error {
-	admin, err := newAdmin(broker)
-	if err != nil {
-		return err
+	parts := make([]string, len(categories))
+	for i, c := range categories {
+		parts[i] = fmt.Sprintf("%s=%d", c, counts[c])
 	}
-	defer admin.Close()
+	return strings.Join(parts, ", ")
+}
 
-	topics, err := admin.ListTopics()
+// exportTopics 导出 topic 到 JSON/YAML 文件；split 时 out 为目录，每个 topic 写一个文件，
+// compression 非空时压缩写出的文件；merge 时把结果合并进已存在的 out 文件，返回合并后的文件
+func exportTopics(ctx context.Context, conn *Config, out, format, compression string, split, merge bool, opts topicctl.ExportOptions) (*topicctl.ExportFile, error) {
+	admin, err := newAdmin(conn)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	defer admin.Close()
 
-	var result []Topic
-	for name, detail := range topics {
-		if excludeInternal && len(name) >= 2 && name[:2] == "__" {
-			continue
+	if opts.ActiveWithin > 0 {
+		client, err := newClient(conn)
+		if err != nil {
+			return nil, err
 		}
+		defer client.Close()
+		opts.Client = client
+	}
 
-		// map[string]*string -> map[string]string
-		configs := make(map[string]string)
-		for k, v := range detail.ConfigEntries {
-			if v != nil {
-				configs[k] = *v
-			} else {
-				configs[k] = ""
+	opts.KafkaVersion = conn.KafkaVersion
+	file, err := topicctl.ExportTopics(ctx, admin, opts)
+	if err != nil {
+		return nil, err
+	}
+	if merge {
+		if _, statErr := os.Stat(out); statErr == nil {
+			existing, err := topicctl.LoadFile(out, format)
+			if err != nil {
+				return nil, fmt.Errorf("读取待合并的文件失败: %w", err)
 			}
+			file = topicctl.MergeFiles(existing, file)
 		}
-
-		result = append(result, Topic{
-			Name:              name,
-			Partitions:        detail.NumPartitions,
-			ReplicationFactor: detail.ReplicationFactor,
-			Configs:           configs,
-		})
 	}
 
-	sort.Slice(result, func(i, j int) bool {
-		return result[i].Name < result[j].Name
-	})
-
-	file := ExportFile{
-		KafkaVersion: "2.4.0",
-		ExportTime:   time.Now().Format(time.RFC3339),
-		Topics:       result,
+	if split {
+		err = topicctl.WriteDir(out, format, file)
+	} else {
+		err = topicctl.WriteFileCompressed(out, format, compression, file)
 	}
-
-	data, _ := json.MarshalIndent(file, "", "  ")
-	return os.WriteFile(out, data, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return file, nil
 }
 
-// importTopics 从 JSON 文件导入 topic
-func importTopics(broker, in string, ifNotExists bool) error {
-	admin, err := newAdmin(broker)
+// streamExport 以 NDJSON 逐个写出 topic，不构建完整的导出文件和编码结果（topic 列表本身仍一次性读入，见 topicctl.StreamTopics），返回导出的 topic 数
+func streamExport(ctx context.Context, conn *Config, out, compression string, opts topicctl.ExportOptions) (int, error) {
+	admin, err := newAdmin(conn)
 	if err != nil {
-		return err
+		return 0, err
 	}
 	defer admin.Close()
 
-	data, err := os.ReadFile(in)
-	if err != nil {
-		return err
+	if opts.ActiveWithin > 0 {
+		client, err := newClient(conn)
+		if err != nil {
+			return 0, err
+		}
+		defer client.Close()
+		opts.Client = client
 	}
 
-	var file ExportFile
-	if err := json.Unmarshal(data, &file); err != nil {
-		return err
+	w, err := topicctl.NewTopicWriter(out, compression)
+	if err != nil {
+		return 0, err
+	}
+	_, err = topicctl.StreamTopics(ctx, admin, opts, w.Write)
+	if cErr := w.Close(); err == nil {
+		err = cErr
 	}
+	return w.Count(), err
+}
 
-	for _, t := range file.Topics {
-		// map[string]string -> map[string]*string
-		cfg := make(map[string]*string)
-		for k, v := range t.Configs {
-			vCopy := v // 避免取地址错误
-			cfg[k] = &vCopy
-		}
+// printSummary 打印导出 topic 的数量、分区总数和副本数分布
+func printSummary(w io.Writer, topics []topicctl.Topic) {
+	partitions := 0
+	byRF := make(map[int16]int)
+	for _, t := range topics {
+		partitions += int(t.Partitions)
+		byRF[t.ReplicationFactor]++
+	}
 
-		detail := &sarama.TopicDetail{
-			NumPartitions:     t.Partitions,
-			ReplicationFactor: t.ReplicationFactor,
-			ConfigEntries:     cfg,
-		}
+	rfs := make([]int16, 0, len(byRF))
+	for rf := range byRF {
+		rfs = append(rfs, rf)
+	}
+	sort.Slice(rfs, func(i, j int) bool { return rfs[i] < rfs[j] })
 
-		err := admin.CreateTopic(t.Name, detail, false)
-		if err != nil {
-			if ifNotExists {
-				fmt.Printf("⚠️  跳过已存在 topic: %s\n", t.Name)
-				continue
-			}
-			return err
-		}
+	fmt.Fprintf(w, "📊 topic 总数: %d\n", len(topics))
+	fmt.Fprintf(w, "📊 分区总数: %d\n", partitions)
+	fmt.Fprintln(w, "📊 副本数分布:")
+	for _, rf := range rfs {
+		fmt.Fprintf(w, "  replication_factor=%d: %d 个 topic\n", rf, byRF[rf])
+	}
+}
 
-		fmt.Printf("✅ 创建 topic: %s\n", t.Name)
+// fatal 把错误输出到 stderr，并按 exitCode 确定的退出码退出
+func fatal(err error) {
+	if lg.json {
+		data, _ := json.Marshal(topicctl.Event{Action: os.Args[1], Status: "error", Error: err.Error()})
+		fmt.Fprintln(os.Stderr, string(data))
+	} else {
+		fmt.Fprintln(os.Stderr, "error:", err)
 	}
+	exit(exitCode(err))
+}
 
-	return nil
+// printUsage 打印子命令列表、示例和退出码约定
+func printUsage() {
+	fmt.Println("用法: kafka-topicctl <export|import|diff|delete|describe|validate|migrate|list|reassign|groups|create|brokers|apply|broker-config|acl|compare|normalize|schema|health|export-offsets|import-offsets|truncate|unthrottle|elect-leaders> [参数]")
+	fmt.Println("示例:")
+	fmt.Println("  kafka-topicctl export --bootstrap broker:9092")
+	fmt.Println("  kafka-topicctl import --bootstrap broker:9092 --in topics.json")
+	fmt.Println("  kafka-topicctl diff --bootstrap broker:9092 --in topics.json")
+	fmt.Println("  kafka-topicctl delete --bootstrap broker:9092 --topics a,b")
+	fmt.Println("  kafka-topicctl describe --bootstrap broker:9092 --topic orders")
+	fmt.Println("  kafka-topicctl validate --in topics.json")
+	fmt.Println("  kafka-topicctl migrate --source-bootstrap staging:9092 --dest-bootstrap prod:9092")
+	fmt.Println("  kafka-topicctl list --bootstrap broker:9092 --sort partitions")
+	fmt.Println("  kafka-topicctl list --bootstrap broker:9092 --exclude-prefix tmp. --exclude-prefix test-")
+	fmt.Println("  kafka-topicctl list --bootstrap-srv _kafka._tcp.example.com")
+	fmt.Println("  kafka-topicctl list --bootstrap broker:9092 --template '{{.Name}} {{index .Configs \"retention.ms\"}}'")
+	fmt.Println("  kafka-topicctl reassign --bootstrap broker:9092 --plan plan.json [--status]")
+	fmt.Println("  kafka-topicctl groups --bootstrap broker:9092 --describe")
+	fmt.Println("  kafka-topicctl groups reset-offsets --bootstrap broker:9092 --group g --to-earliest")
+	fmt.Println("  kafka-topicctl create --bootstrap broker:9092 --name-template orders-{i} --count 32 --partitions 6")
+	fmt.Println("  kafka-topicctl brokers --bootstrap broker:9092 [--controller-only]")
+	fmt.Println("  kafka-topicctl apply --bootstrap broker:9092 --in topics.json [--prune --yes]")
+	fmt.Println("  kafka-topicctl apply --bootstrap broker:9092 --in topics.json --only-diff")
+	fmt.Println("  kafka-topicctl broker-config <get|set> --bootstrap broker:9092 --broker 1 [--set key=value]")
+	fmt.Println("  kafka-topicctl broker-config get --bootstrap broker:9092 --resource-type broker-logger --resource-name 1")
+	fmt.Println("  kafka-topicctl broker-config set --bootstrap broker:9092 --cluster-default --set log.retention.ms=604800000")
+	fmt.Println("  kafka-topicctl acl <list|create|delete> --bootstrap broker:9092 --principal User:alice")
+	fmt.Println("  kafka-topicctl compare --left active:9092 --right passive:9092")
+	fmt.Println("  kafka-topicctl compare --left active:9092 --right dc2:9092 --right dc3:9092 --continue-on-connect-error")
+	fmt.Println("  kafka-topicctl normalize --in topics.json")
+	fmt.Println("  kafka-topicctl schema --out topics.schema.json")
+	fmt.Println("  kafka-topicctl health --bootstrap broker:9092")
+	fmt.Println("  kafka-topicctl export-offsets --bootstrap broker:9092 --out offsets.json")
+	fmt.Println("  kafka-topicctl import-offsets --bootstrap broker:9092 --in offsets.json")
+	fmt.Println("  kafka-topicctl truncate --bootstrap broker:9092 --topic orders --before-timestamp 2024-01-01T00:00:00Z --yes")
+	fmt.Println("  kafka-topicctl unthrottle --bootstrap broker:9092 [--include '^orders']")
+	fmt.Println("  kafka-topicctl elect-leaders --bootstrap broker:9092 [--topics orders,payments] [--type preferred]")
+	fmt.Println(exitCodesHelp)
 }
 
 // main 入口
 func main() {
+	// 未预期的 panic 也按运行时错误退出，而不是 Go 运行时默认的状态码 2（与用法错误冲突）
+	defer func() {
+		if r := recover(); r != nil {
+			fmt.Fprintf(os.Stderr, "%s", debug.Stack())
+			fatal(fmt.Errorf("内部错误: %v", r))
+		}
+	}()
+
 	if len(os.Args) < 2 {
-		fmt.Println("用法: kafka-topicctl <export|import> [参数]")
-		fmt.Println("示例:")
-		fmt.Println("  kafka-topicctl export --bootstrap broker:9092")
-		fmt.Println("  kafka-topicctl import --bootstrap broker:9092 --in topics.json")
-		os.Exit(1)
+		printUsage()
+		exit(exitUsage)
+	}
+	switch os.Args[1] {
+	case "-h", "-help", "--help", "help":
+		printUsage()
+		exit(exitOK)
 	}
 
+	// SIGINT/SIGTERM 取消 ctx，正在执行的命令不再发起新请求并报告已完成的进度；
+	// 取消后恢复默认信号处理，再次按 Ctrl-C 会直接退出
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+
+	start := time.Now()
+
 	switch os.Args[1] {
 
 	case "export":
-		fs := flag.NewFlagSet("export", flag.ExitOnError)
-		broker := fs.String("bootstrap", "", "Kafka bootstrap server")
-		out := fs.String("out", "topics.json", "输出文件（默认当前目录 topics.json）")
-		exclude := fs.Bool("exclude-internal", true, "排除内部 topic（默认 true）")
-		fs.Parse(os.Args[2:])
+		fs := flag.NewFlagSet("export", flag.ContinueOnError)
+		conn := bindConnFlags(fs)
+		out := fs.String("out", "topics.json", "输出文件（默认当前目录 topics.json，- 表示 stdout）")
+		outputDir := fs.String("output-dir", "", "按 topic 拆分导出到该目录，每个 topic 一个 <topic>.json（覆盖 --out）")
+		format := fs.String("format", "auto", "文件格式: json / yaml / ndjson / auto（按扩展名判断，.ndjson/.jsonl 为 ndjson；ndjson 时每个 topic 一行，边查询边写出）")
+		excludeInternal := fs.Bool("exclude-internal", true, "排除内部 topic（默认 true）")
+		include := fs.String("include", "", "只导出名称匹配该正则的 topic")
+		exclude := fs.String("exclude", "", "排除名称匹配该正则的 topic（在 --include 之后生效）")
+		includePrefixes, excludePrefixes := bindPrefixFlags(fs)
+		var excludeConfigs []string
+		fs.Func("exclude-config", "导出时剔除的配置项 key（可重复或用逗号分隔）", func(v string) error {
+			excludeConfigs = append(excludeConfigs, splitList(v)...)
+			return nil
+		})
+		summary := fs.Bool("summary", false, "导出后打印 topic 数、分区总数和副本数分布")
+		printCount := fs.Bool("print-count", false, "stdout 只输出导出的 topic 数，便于脚本读取")
+		includeDefaults := fs.Bool("include-defaults", false, "额外记录每个 topic 的全部生效配置并标记默认值（导入时不会应用）")
+		onlyConfigs := fs.Bool("only-configs", false, "只导出 topic 名称和配置，省略分区数和副本数（导入、校验这类文件需加 --allow-unspecified）")
+		includePartitionDetail := fs.Bool("include-partition-detail", false, "额外记录每个分区的 leader、副本和 ISR（导入时忽略，diff 会对比 leader / ISR 的变化）")
+		compress := fs.String("compress", "", "压缩导出文件: gzip / zstd（自动追加 .gz / .zst 扩展名）")
+		hash := fs.Bool("hash", false, "打印导出 topic 列表的 SHA-256，集群不变时结果稳定")
+		hashFile := fs.Bool("hash-file", false, "同时把 SHA-256 写入 <out>.sha256（需配合 --hash）")
+		minRetention := fs.Int64("min-retention-ms", 0, "只导出 retention.ms 不小于该值的 topic（0 表示不限，-1 永久保留视为无穷大）")
+		maxRetention := fs.Int64("max-retention-ms", 0, "只导出 retention.ms 不大于该值的 topic（0 表示不限）")
+		includeDefaultRetention := fs.Bool("include-default-retention", false, "按保留时间筛选时也导出没有 retention.ms 覆盖项的 topic")
+		metricsFile := fs.String("metrics-file", "", "运行结束后把 Prometheus 文本格式的指标写入该文件")
+		var redact []string
+		fs.Func("redact", "把这些配置项的值替换为 "+topicctl.Redacted+"（可重复或用逗号分隔），导入时会跳过", func(v string) error {
+			redact = append(redact, splitList(v)...)
+			return nil
+		})
+		watch := fs.Duration("watch", 0, "每隔该时间导出一次，topic 或配置变化时才写出带时间戳的新文件，如 5m（Ctrl-C 退出）")
+		merge := fs.Bool("merge", false, "把导出的 topic 按名称合并进已存在的 --out 文件（同名替换，其余保留），而不是覆盖")
+		noTimestamp := fs.Bool("no-timestamp", false, "不在导出文件中记录导出时间，集群不变时重复导出的文件完全一致")
+		exportTime := fs.String("export-time", "", "用该值代替当前时间写入导出文件，如 2024-01-01T00:00:00Z")
+		sample := fs.Int("sample", 0, "在 --include / --exclude 等筛选之后随机导出 N 个 topic，用于生成测试数据")
+		seed := fs.Int64("seed", 0, "--sample 使用的随机数种子，相同种子选中的 topic 相同（0 表示随机，实际种子会输出到日志）")
+		activeWithin := fs.Duration("active-within", 0, "只导出这段时间内有写入的 topic，如 24h（按消息时间戳判断，没有带时间戳消息的 topic 会被排除）")
+		bindLogFlags(fs)
+		parseFlags(fs, conn)
 
-		if *broker == "" {
-			fs.Usage()
-			os.Exit(1)
+		if len(conn.brokers()) == 0 {
+			usage(fs)
 		}
 
-		if err := exportTopics(*broker, *out, *exclude); err != nil {
-			panic(err)
+		filter, err := topicctl.NewFilter(*excludeInternal, *include, *exclude)
+		if err != nil {
+			fatal(err)
 		}
+		filter.IncludePrefixes, filter.ExcludePrefixes = *includePrefixes, *excludePrefixes
+		filter.ExcludeConfig(excludeConfigs...)
 
-		fmt.Println("🎉 导出完成:", *out)
+		if *sample < 0 {
+			fatal(usageError("--sample 不能为负数"))
+		}
+		if *sample > 0 && *seed == 0 {
+			*seed = time.Now().UnixNano()
+		}
+		if *noTimestamp && *exportTime != "" {
+			fatal(usageError("--no-timestamp 不能与 --export-time 同时使用"))
+		}
+
+		split := *outputDir != ""
+		if *merge && (split || *out == topicctl.Stdio) {
+			fatal(usageError("--merge 不能与 --output-dir 或 --out - 同时使用"))
+		}
+		if *watch > 0 && (split || *merge || *out == topicctl.Stdio) {
+			fatal(usageError("--watch 不能与 --output-dir、--merge 或 --out - 同时使用"))
+		}
+		if split {
+			if *compress != "" {
+				fatal(usageError("--compress 不能与 --output-dir 同时使用"))
+			}
+			*out = *outputDir
+		}
+		ext, err := topicctl.CompressExt(*compress)
+		if err != nil {
+			fatal(err)
+		}
+		if *out != topicctl.Stdio && !strings.HasSuffix(*out, ext) {
+			*out += ext
+		}
+		// --print-count 时 stdout 只输出 topic 数，--out - 时 stdout 只输出文件内容，其余信息改走 stderr
+		if *printCount || *out == topicctl.Stdio {
+			lg.out = os.Stderr
+		}
+		opts := topicctl.ExportOptions{
+			Filter:          filter,
+			IncludeDefaults: *includeDefaults,
+			OnlyConfigs:     *onlyConfigs,
+
+			IncludePartitionDetail: *includePartitionDetail,
+			Retention: &topicctl.RetentionRange{
+				Min:            *minRetention,
+				Max:            *maxRetention,
+				IncludeDefault: *includeDefaultRetention,
+			},
+			Redact:       redact,
+			ExportTime:   *exportTime,
+			NoTimestamp:  *noTimestamp,
+			ActiveWithin: *activeWithin,
+			Sample:       *sample,
+			Seed:         *seed,
+			Log:          lg.log,
+		}
+		if *sample > 0 {
+			lg.log(topicctl.Event{
+				Action: "export", Status: "sample", Detail: fmt.Sprintf("sample=%d, seed=%d", *sample, *seed),
+				Message: fmt.Sprintf("🎲 随机导出 %d 个 topic，seed=%d（用 --seed 复现）", *sample, *seed),
+			})
+		}
+		if *watch > 0 {
+			if err := watchExport(ctx, conn, *out, *format, *compress, *watch, opts); err != nil {
+				fatal(err)
+			}
+			return
+		}
+
+		// ndjson 时边查询边写出；--merge / --summary / --hash 需要完整列表，仍一次性写出
+		resolved, err := topicctl.ResolveFormat(*out, *format)
+		if err != nil {
+			fatal(err)
+		}
+		if split && resolved == topicctl.FormatNDJSON {
+			fatal(usageError("--format ndjson 不能与 --output-dir 同时使用"))
+		}
+		var file *topicctl.ExportFile
+		exported := 0
+		if resolved == topicctl.FormatNDJSON && !*merge && !*summary && !*hash {
+			exported, err = streamExport(ctx, conn, *out, *compress, opts)
+		} else {
+			file, err = exportTopics(ctx, conn, *out, *format, *compress, split, *merge, opts)
+			if file != nil {
+				exported = len(file.Topics)
+			}
+		}
+		if mErr := writeMetrics(*metricsFile, "export", start, err == nil,
+			metric{"kafka_topicctl_topics_exported", "导出的 topic 数", float64(exported)},
+		); mErr != nil {
+			fmt.Fprintln(os.Stderr, "⚠️ ", mErr)
+		}
+		if err != nil {
+			fatal(err)
+		}
+
+		lg.log(topicctl.Event{
+			Action: "export", Status: "done", Detail: *out,
+			Message: fmt.Sprintf("🎉 导出完成: %s (%d 个 topic)", *out, exported),
+		})
+		if *summary {
+			printSummary(lg.out, file.Topics)
+		}
+		if *hash {
+			sum, err := topicctl.Hash(file.Topics)
+			if err != nil {
+				fatal(err)
+			}
+			lg.log(topicctl.Event{
+				Action: "hash", Status: "done", Detail: sum,
+				Message: "🔑 sha256: " + sum,
+			})
+			if *hashFile && *out != topicctl.Stdio {
+				if err := os.WriteFile(strings.TrimSuffix(*out, "/")+".sha256", []byte(sum+"\n"), 0644); err != nil {
+					fatal(err)
+				}
+			}
+		}
+		if *printCount {
+			fmt.Println(exported)
+		}
 
 	case "import":
-		fs := flag.NewFlagSet("import", flag.ExitOnError)
-		broker := fs.String("bootstrap", "", "Kafka bootstrap server")
-		in := fs.String("in", "topics.json", "导入文件（默认当前目录 topics.json）")
+		fs := flag.NewFlagSet("import", flag.ContinueOnError)
+		conn := bindConnFlags(fs)
+		in := fs.String("in", "topics.json", "导入文件或目录（默认当前目录 topics.json，- 表示 stdin，目录时读取其中每个 topic 的文件）")
+		format := fs.String("format", "auto", "文件格式: json / yaml / ndjson / auto（按扩展名判断）")
 		ifNotExists := fs.Bool("if-not-exists", true, "存在则跳过（默认 true）")
-		fs.Parse(os.Args[2:])
+		dryRun := fs.Bool("dry-run", false, "只打印将要创建的 topic，不实际创建")
+		alterPartitions := fs.Bool("alter-partitions", false, "已存在的 topic 分区数少于文件时扩容（不会缩减）")
+		alterConfigs := fs.Bool("alter-configs", false, "已存在的 topic 配置与文件不一致时修改")
+		deleteMissingConfigs := fs.Bool("delete-missing-configs", false, "配合 --alter-configs，删除 topic 上存在而文件中没有的配置覆盖项（默认保留）")
+		concurrency := fs.Int("concurrency", 1, "并发创建 topic 的数量")
+		strict := fs.Bool("strict", false, "导入前按内嵌 JSON Schema 严格校验文件；配合 --topic-config-validate 时未知配置项视为错误")
+		configValidate := fs.Bool("topic-config-validate", false, "导入前按 broker 认识的 topic 配置项检查文件中的配置 key（默认只告警）")
+		expandAliases := fs.Bool("expand-config-aliases", false, "展开配置简写，如 retention=7d -> retention.ms=604800000、retention.size=500mb -> retention.bytes；*.ms / *.bytes 的值也可带单位")
+		failFast := fs.Bool("fail-fast", false, "遇到第一个错误即停止（默认处理完全部 topic 后汇总报错）")
+		topics := fs.String("topics", "", "只导入文件中的这些 topic（多个用逗号分隔）")
+		serverValidate := fs.Bool("server-validate", false, "由 broker 以 validateOnly 方式校验创建请求，不实际创建")
+		replicationFactor := fs.Int("replication-factor", 0, "用该值覆盖文件中每个 topic 的副本数（0 表示不覆盖）")
+		maxReplicationFactor := fs.Bool("max-replication-factor", false, "副本数超过集群 broker 数时降到 broker 数")
+		var rename topicctl.NameTransform
+		fs.StringVar(&rename.StripPrefix, "strip-prefix", "", "导入前去掉 topic 名称的该前缀，如 prod.")
+		fs.StringVar(&rename.StripSuffix, "strip-suffix", "", "导入前去掉 topic 名称的该后缀")
+		fs.StringVar(&rename.AddPrefix, "add-prefix", "", "导入前给 topic 名称加上该前缀（在去前缀/后缀之后），如 staging.")
+		fs.StringVar(&rename.AddSuffix, "add-suffix", "", "导入前给 topic 名称加上该后缀（在去前缀/后缀之后）")
+		partitionsMultiplier := fs.Float64("partitions-multiplier", 0, "把每个 topic 的分区数乘以该系数，向上取整且至少为 1（0 表示不调整）")
+		batchSize := fs.Int("batch-size", 0, "每个 CreateTopics 请求合并提交的 topic 数，broker 在一个 --timeout 内处理整批（0 或 1 表示逐个提交）")
+		topicTimeout := fs.Duration("topic-timeout", 0, "单个 topic 创建请求的超时时间，超时记为失败并继续（0 表示不限）")
+		allowUnset := fs.Bool("allow-unset", false, "配置中引用的 ${VAR} 未设置时替换为空串而不是报错")
+		warnOnly := fs.Bool("warn-only", false, "min.insync.replicas 大于副本数时只告警，不中止导入")
+		allowUnspecified := fs.Bool("allow-unspecified", false, "允许文件中省略 partitions / replication_factor（或为 0），创建时使用 broker 默认值；需要 Kafka >= 2.4")
+		metricsFile := fs.String("metrics-file", "", "运行结束后把 Prometheus 文本格式的指标写入该文件")
+		baseConfig := fs.String("base-config", "", "公共配置文件（{\"configs\": {...}}），合并到每个 topic 的配置之下，topic 自身的配置优先")
+		bindLogFlags(fs)
+		parseFlags(fs, conn)
+
+		if len(conn.brokers()) == 0 {
+			usage(fs)
+		}
+
+		if *batchSize > 1 && *topicTimeout > 0 {
+			fatal(usageError("--topic-timeout 不能与 --batch-size 同时使用，批量提交时由 --timeout 控制整批的超时"))
+		}
+		version, err := conn.version()
+		if err != nil {
+			fatal(err)
+		}
+		if *partitionsMultiplier < 0 {
+			fatal(usageError("--partitions-multiplier 不能为负数"))
+		}
+		if *allowUnspecified {
+			if err := conn.checkAllowUnspecified(); err != nil {
+				fatal(err)
+			}
+		}
+		if *serverValidate && (*alterPartitions || *alterConfigs) {
+			fatal(usageError("--server-validate 不能与 --alter-partitions / --alter-configs 同时使用"))
+		}
+
+		opts := topicctl.ImportOptions{
+			IfNotExists:     *ifNotExists,
+			DryRun:          *dryRun,
+			AlterPartitions: *alterPartitions,
+			AlterConfigs:    *alterConfigs,
+			Concurrency:     *concurrency,
+			FailFast:        *failFast,
+			ServerValidate:  *serverValidate,
+			TopicTimeout:    *topicTimeout,
+			BatchSize:       *batchSize,
+			BatchTimeout:    conn.Timeout,
+			KafkaVersion:    version,
+			Topics:          splitList(*topics),
+			Rename:          rename,
+
+			DeleteMissingConfigs: *deleteMissingConfigs,
+			ReplicationFactor:    int16(*replicationFactor),
+			MaxReplicationFactor: *maxReplicationFactor,
+			PartitionsMultiplier: *partitionsMultiplier,
+			AllowUnspecified:     *allowUnspecified,
+
+			Timing: conn.Debug, // --debug 时记录并汇总每个创建请求的耗时
+		}
+		src := importSource{
+			In: *in, Format: *format,
+			Strict:     *strict,
+			BaseConfig: *baseConfig,
+			AllowUnset: *allowUnset,
+			WarnOnly:   *warnOnly,
+
+			AllowUnspecified: *allowUnspecified,
+
+			ConfigValidate: *configValidate,
+			ExpandAliases:  *expandAliases,
+		}
+		res, err := importTopics(ctx, conn, src, opts)
+		if mErr := writeMetrics(*metricsFile, "import", start, err == nil,
+			metric{"kafka_topicctl_topics_imported", "创建（或 dry-run / server-validate 时将创建）的 topic 数", float64(len(res.Created))},
+			metric{"kafka_topicctl_topics_altered", "修改的 topic 数", float64(len(res.Altered))},
+			metric{"kafka_topicctl_topics_skipped", "跳过的 topic 数", float64(len(res.Skipped))},
+			metric{"kafka_topicctl_topics_failed", "处理失败的 topic 数", float64(len(res.Failed))},
+		); mErr != nil {
+			fmt.Fprintln(os.Stderr, "⚠️ ", mErr)
+		}
+		if l := res.Latency; l != nil {
+			detail := fmt.Sprintf("count=%d, min=%s, p50=%s, p95=%s, max=%s", l.Count, l.Min, l.P50, l.P95, l.Max)
+			lg.log(topicctl.Event{
+				Action: "import", Status: "latency", Detail: detail,
+				Message: "⏱️  创建请求耗时: " + detail,
+			})
+		}
+		if len(res.TimedOut) > 0 {
+			lg.log(topicctl.Event{
+				Action: "import", Status: "warning", Detail: strings.Join(res.TimedOut, ","),
+				Message: fmt.Sprintf("⏱️  %d 个 topic 创建超时: %s", len(res.TimedOut), strings.Join(res.TimedOut, ", ")),
+			})
+		}
+		if len(res.Failed) > 0 {
+			byCategory := formatCategories(res.CountByCategory())
+			lg.log(topicctl.Event{
+				Action: "import", Status: "error", Detail: byCategory,
+				Message: fmt.Sprintf("❌ %d 个 topic 失败，按类别: %s", len(res.Failed), byCategory),
+			})
+		}
+		if err != nil {
+			fatal(partial(err, len(res.Created)+len(res.Altered)+len(res.Skipped)))
+		}
+
+		counts := fmt.Sprintf("创建 %d 个, 跳过 %d 个", len(res.Created), len(res.Skipped))
+		if len(res.Altered) > 0 {
+			counts = fmt.Sprintf("创建 %d 个, 修改 %d 个, 跳过 %d 个", len(res.Created), len(res.Altered), len(res.Skipped))
+		}
+		switch {
+		case *serverValidate:
+			lg.log(topicctl.Event{
+				Action: "import", Status: "server-validate", Detail: counts,
+				Message: fmt.Sprintf("🎉 broker 校验完成，未做任何修改（%d 个 topic 通过校验）", len(res.Created)),
+			})
+		case *dryRun:
+			lg.log(topicctl.Event{
+				Action: "import", Status: "dry-run", Detail: counts,
+				Message: fmt.Sprintf("🎉 dry-run 完成，未做任何修改（将%s）", counts),
+			})
+		default:
+			lg.log(topicctl.Event{
+				Action: "import", Status: "done", Detail: counts,
+				Message: fmt.Sprintf("🎉 导入完成: %s", counts),
+			})
+			if res.Changed() {
+				exit(exitChanged)
+			}
+		}
+
+	case "diff":
+		fs := flag.NewFlagSet("diff", flag.ContinueOnError)
+		conn := bindConnFlags(fs)
+		in := fs.String("in", "topics.json", "对比文件（默认当前目录 topics.json，- 表示 stdin）")
+		format := fs.String("format", "auto", "文件格式: json / yaml / ndjson / auto（按扩展名判断）")
+		exclude := fs.Bool("exclude-internal", true, "排除内部 topic（默认 true）")
+		output := fs.String("output", "text", "输出格式: text / json（--format 用于指定对比文件的格式）")
+		parseFlags(fs, conn)
+
+		if len(conn.brokers()) == 0 || (*output != "text" && *output != "json") {
+			usage(fs)
+		}
+
+		d, err := diffCluster(conn, *in, *format, *exclude)
+		if err != nil {
+			fatal(err)
+		}
+
+		if *output == "json" {
+			if err := writeDiffJSON(os.Stdout, d); err != nil {
+				fatal(err)
+			}
+			if !d.Empty() {
+				exit(exitChanged)
+			}
+			return
+		}
+
+		printDiff(d)
+		if !d.Empty() {
+			exit(exitChanged)
+		}
+		fmt.Println("🎉 文件与集群一致")
+
+	case "delete":
+		fs := flag.NewFlagSet("delete", flag.ContinueOnError)
+		conn := bindConnFlags(fs)
+		topics := fs.String("topics", "", "要删除的 topic（多个用逗号分隔）")
+		yes := fs.Bool("yes", false, "跳过交互确认")
+		bindAllowInternal(fs, conn)
+		parseFlags(fs, conn)
+
+		names := splitList(*topics)
+		if len(conn.brokers()) == 0 || len(names) == 0 {
+			usage(fs)
+		}
+		if err := checkDeletable(conn, names); err != nil {
+			fatal(err)
+		}
+
+		if !*yes && !confirm("topic", names) {
+			fmt.Println("已取消")
+			exit(exitError)
+		}
+
+		if deleted, err := deleteTopics(conn, names); err != nil {
+			fatal(partial(err, deleted))
+		}
+
+		fmt.Println("🎉 删除完成")
+
+	case "describe":
+		fs := flag.NewFlagSet("describe", flag.ContinueOnError)
+		conn := bindConnFlags(fs)
+		topic := fs.String("topic", "", "要查看的 topic")
+		tmplText := fs.String("template", "", templateHelp)
+		parseFlags(fs, conn)
+
+		if len(conn.brokers()) == 0 || *topic == "" {
+			usage(fs)
+		}
+		tmpl, err := parseTopicTemplate(*tmplText)
+		if err != nil {
+			fatal(usageError(err.Error()))
+		}
+
+		if err := describeTopic(conn, *topic, tmpl); err != nil {
+			fatal(err)
+		}
+
+	case "validate":
+		fs := flag.NewFlagSet("validate", flag.ContinueOnError)
+		in := fs.String("in", "topics.json", "要检查的文件（默认当前目录 topics.json，- 表示 stdin）")
+		format := fs.String("format", "auto", "文件格式: json / yaml / ndjson / auto（按扩展名判断）")
+		strict := fs.Bool("strict", false, "先按内嵌 JSON Schema 严格校验文件结构")
+		warnOnly := fs.Bool("warn-only", false, "min.insync.replicas 大于副本数时只告警，不计为问题")
+		allowUnspecified := fs.Bool("allow-unspecified", false, "允许省略 partitions / replication_factor（或为 0），如 export --only-configs 的文件；导入时需要 Kafka >= 2.4")
+		parseOnly(fs, os.Args[2:])
+		validateOpts := topicctl.ValidateOptions{AllowUnspecified: *allowUnspecified}
+
+		report := func(problems []string) {
+			for _, p := range problems {
+				fmt.Fprintf(os.Stderr, "❌ %s: %s\n", *in, p)
+			}
+			if len(problems) > 0 {
+				fmt.Fprintf(os.Stderr, "共发现 %d 个问题\n", len(problems))
+				exit(exitError)
+			}
+		}
+
+		// --strict 时先做 schema 校验，结构不合法就不再解析为结构体
+		if *strict {
+			problems, err := topicctl.CheckSchemaWith(*in, *format, validateOpts)
+			if err != nil {
+				fatal(err)
+			}
+			report(problems)
+		}
+
+		file, err := topicctl.LoadFile(*in, *format)
+		if err != nil {
+			fatal(err)
+		}
+		problems := topicctl.ValidateWith(file, validateOpts)
+		for _, p := range topicctl.CheckMinISR(file) {
+			if *warnOnly {
+				fmt.Fprintf(os.Stderr, "⚠️  %s: %s\n", *in, p)
+				continue
+			}
+			problems = append(problems, p)
+		}
+		report(problems)
+
+		fmt.Printf("🎉 校验通过: %s (%d 个 topic)\n", *in, len(file.Topics))
+
+	case "migrate":
+		fs := flag.NewFlagSet("migrate", flag.ContinueOnError)
+		conn := bindClientFlags(fs)
+		source := fs.String("source-bootstrap", "", "源集群 bootstrap server（多个用逗号分隔）")
+		var dests clusterList
+		fs.Var(&dests, "dest-bootstrap", "目标集群 bootstrap server（多个 broker 用逗号分隔；可重复指定以迁移到多个集群）")
+		continueOnConnectError := fs.Bool("continue-on-connect-error", false, "某个目标集群无法连接时记录并继续处理其余集群，结束时汇总并以非零状态退出")
+		excludeInternal := fs.Bool("exclude-internal", true, "排除内部 topic（默认 true）")
+		include := fs.String("include", "", "只迁移名称匹配该正则的 topic")
+		exclude := fs.String("exclude", "", "排除名称匹配该正则的 topic（在 --include 之后生效）")
+		ifNotExists := fs.Bool("if-not-exists", true, "目标集群已存在则跳过（默认 true）")
+		dryRun := fs.Bool("dry-run", false, "只打印将要创建的 topic，不实际创建")
+		concurrency := fs.Int("concurrency", 1, "并发创建 topic 的数量")
+		failFast := fs.Bool("fail-fast", false, "遇到第一个错误即停止（默认处理完全部 topic 后汇总报错）")
+		bindLogFlags(fs)
+		parseFlags(fs, conn)
+
+		srcConn := *conn
+		srcConn.Bootstrap = *source
+		if len(srcConn.brokers()) == 0 || len(dests) == 0 {
+			usage(fs)
+		}
+
+		filter, err := topicctl.NewFilter(*excludeInternal, *include, *exclude)
+		if err != nil {
+			fatal(err)
+		}
+
+		opts := topicctl.ImportOptions{
+			IfNotExists: *ifNotExists,
+			DryRun:      *dryRun,
+			Concurrency: *concurrency,
+			FailFast:    *failFast,
+		}
+		// 源集群只列一次；源集群连接失败始终是致命错误
+		topics, err := listClusterTopics(&srcConn, filter)
+		if err != nil {
+			fatal(fmt.Errorf("读取源集群失败: %w", err))
+		}
+
+		var unreachable []string
+		for _, dest := range dests {
+			destConn := *conn
+			destConn.Bootstrap = dest
+			res, err := migrateTopics(ctx, &destConn, topics, opts)
+			if skipUnreachable(err, *continueOnConnectError, &unreachable) {
+				continue
+			}
+			if err != nil {
+				fatal(partial(err, len(res.Created)+len(res.Skipped)))
+			}
+
+			lg.log(topicctl.Event{
+				Action: "migrate", Status: "done",
+				Detail:  fmt.Sprintf("dest=%s, created=%d, skipped=%d", dest, len(res.Created), len(res.Skipped)),
+				Message: fmt.Sprintf("🎉 迁移到 %s 完成: 创建 %d 个，跳过 %d 个", dest, len(res.Created), len(res.Skipped)),
+			})
+		}
+		reportUnreachable(unreachable)
+
+	case "list":
+		fs := flag.NewFlagSet("list", flag.ContinueOnError)
+		conn := bindConnFlags(fs)
+		excludeInternal := fs.Bool("exclude-internal", true, "排除内部 topic（默认 true）")
+		includePrefixes, excludePrefixes := bindPrefixFlags(fs)
+		sortBy := fs.String("sort", "name", "排序字段: name / partitions / replication-factor")
+		activeWithin := fs.Duration("active-within", 0, "只列出这段时间内有写入的 topic，如 24h（按消息时间戳判断，没有带时间戳消息的 topic 会被排除）")
+		tmplText := fs.String("template", "", templateHelp)
+		parseFlags(fs, conn)
+
+		if len(conn.brokers()) == 0 {
+			usage(fs)
+		}
+		tmpl, err := parseTopicTemplate(*tmplText)
+		if err != nil {
+			fatal(usageError(err.Error()))
+		}
+
+		filter := &topicctl.Filter{
+			ExcludeInternal: *excludeInternal,
+			IncludePrefixes: *includePrefixes,
+			ExcludePrefixes: *excludePrefixes,
+		}
+		if err := listTopics(conn, filter, *sortBy, *activeWithin, tmpl); err != nil {
+			fatal(err)
+		}
+
+	case "reassign":
+		fs := flag.NewFlagSet("reassign", flag.ContinueOnError)
+		conn := bindConnFlags(fs)
+		planFile := fs.String("plan", "", "重分配计划文件（JSON）")
+		status := fs.Bool("status", false, "不提交，只轮询计划中分区的重分配进度直到完成")
+		interval := fs.Duration("interval", 5*time.Second, "--status 的轮询间隔")
+		parseFlags(fs, conn)
+
+		if len(conn.brokers()) == 0 || *planFile == "" {
+			usage(fs)
+		}
+
+		plan, err := loadReassignPlan(*planFile)
+		if err != nil {
+			fatal(err)
+		}
+
+		if *status {
+			if err := waitReassignments(conn, plan, *interval); err != nil {
+				fatal(err)
+			}
+			fmt.Println("🎉 重分配已完成")
+			return
+		}
+
+		if err := reassignPartitions(conn, plan); err != nil {
+			fatal(err)
+		}
+		fmt.Println("🎉 重分配已提交，可用 --status 查看进度")
+
+	case "groups":
+		if len(os.Args) > 2 && os.Args[2] == "reset-offsets" {
+			fs := flag.NewFlagSet("groups reset-offsets", flag.ContinueOnError)
+			conn := bindConnFlags(fs)
+			group := fs.String("group", "", "要重置的 group")
+			topic := fs.String("topic", "", "只重置指定 topic（默认 group 已提交的全部 topic）")
+			toEarliest := fs.Bool("to-earliest", false, "重置到最早的 offset")
+			toLatest := fs.Bool("to-latest", false, "重置到最新的 offset")
+			toOffset := fs.Int64("to-offset", -1, "重置到指定 offset")
+			force := fs.Bool("force", false, "即使 group 仍有活跃成员也强制重置")
+			parseArgs(fs, conn, os.Args[3:])
+
+			modes := 0
+			for _, set := range []bool{*toEarliest, *toLatest, *toOffset >= 0} {
+				if set {
+					modes++
+				}
+			}
+			if len(conn.brokers()) == 0 || *group == "" || modes != 1 {
+				fmt.Fprintln(os.Stderr, "必须指定 --group，且 --to-earliest / --to-latest / --to-offset 三选一")
+				usage(fs)
+			}
+
+			target := resetTarget{Earliest: *toEarliest, Latest: *toLatest, Offset: *toOffset}
+			if err := resetGroupOffsets(conn, *group, *topic, target, *force); err != nil {
+				fatal(err)
+			}
+			fmt.Println("🎉 offset 重置完成")
+			return
+		}
+
+		fs := flag.NewFlagSet("groups", flag.ContinueOnError)
+		conn := bindConnFlags(fs)
+		describe := fs.Bool("describe", false, "展开每个 group 的状态、成员数和分配的 topic")
+		offsets := fs.Bool("offsets", false, "显示每个分区的已提交 offset 和 lag")
+		group := fs.String("group", "", "只查看指定的 group")
+		parseFlags(fs, conn)
+
+		if len(conn.brokers()) == 0 {
+			usage(fs)
+		}
+
+		if *offsets {
+			if err := showGroupOffsets(conn, *group); err != nil {
+				fatal(err)
+			}
+			return
+		}
+
+		if err := listGroups(conn, *group, *describe); err != nil {
+			fatal(err)
+		}
+
+	case "create":
+		fs := flag.NewFlagSet("create", flag.ContinueOnError)
+		conn := bindConnFlags(fs)
+		topic := fs.String("topic", "", "topic 名称（可含 {i}，配合 --count 使用）")
+		template := fs.String("name-template", "", "名称模板，{i} 会被替换为 0..count-1（覆盖 --topic）")
+		count := fs.Int("count", 0, "按模板批量创建的 topic 数")
+		partitions := fs.Int("partitions", 1, "分区数")
+		rf := fs.Int("replication-factor", 1, "副本数")
+		configs := configFlags{}
+		fs.Var(configs, "topic-config", "topic 配置 key=value（可重复；--config 已用于指定配置文件）")
+		parseFlags(fs, conn)
+
+		if len(conn.brokers()) == 0 || (*topic == "" && *template == "") {
+			usage(fs)
+		}
+
+		names, err := expandNames(*topic, *template, *count)
+		if err != nil {
+			fatal(err)
+		}
+		if created, err := createTopics(ctx, conn, names, int32(*partitions), int16(*rf), configs); err != nil {
+			fatal(partial(err, created))
+		}
+		fmt.Printf("🎉 创建完成: %d 个 topic\n", len(names))
+
+	case "brokers":
+		fs := flag.NewFlagSet("brokers", flag.ContinueOnError)
+		conn := bindConnFlags(fs)
+		controllerOnly := fs.Bool("controller-only", false, "只输出 controller 的 broker ID")
+		parseFlags(fs, conn)
+
+		if len(conn.brokers()) == 0 {
+			usage(fs)
+		}
+
+		if err := listBrokers(conn, *controllerOnly); err != nil {
+			fatal(err)
+		}
+
+	case "apply":
+		fs := flag.NewFlagSet("apply", flag.ContinueOnError)
+		conn := bindConnFlags(fs)
+		in := fs.String("in", "topics.json", "期望状态文件（默认当前目录 topics.json，- 表示 stdin）")
+		format := fs.String("format", "auto", "文件格式: json / yaml / ndjson / auto（按扩展名判断）")
+		prune := fs.Bool("prune", false, "删除集群中存在而文件中没有的 topic（__ 开头的内部 topic 不参与对比，其余 _ 开头的需加 --allow-internal）")
+		bindAllowInternal(fs, conn)
+		yes := fs.Bool("yes", false, "跳过删除前的交互确认")
+		dryRun := fs.Bool("dry-run", false, "只打印执行计划，不做任何修改")
+		deleteMissingConfigs := fs.Bool("delete-missing-configs", false, "删除 topic 上存在而文件中没有的配置覆盖项（默认保留）")
+		allowUnspecified := fs.Bool("allow-unspecified", false, "允许创建文件中未指定 partitions / replication_factor 的 topic，使用 broker 默认值；需要 Kafka >= 2.4")
+		onlyDiff := fs.Bool("only-diff", false, "只按执行顺序打印 apply 将执行的操作（创建 / 扩容分区 / 修改配置 / 删除），不做任何修改；有待执行的操作时退出码为 10")
+		bindLogFlags(fs)
+		parseFlags(fs, conn)
+
+		if len(conn.brokers()) == 0 {
+			usage(fs)
+		}
+		if *allowUnspecified {
+			if err := conn.checkAllowUnspecified(); err != nil {
+				fatal(err)
+			}
+		}
+
+		err := applyFile(ctx, conn, *in, *format, applyOptions{
+			Prune:                *prune,
+			Yes:                  *yes,
+			DryRun:               *dryRun,
+			OnlyDiff:             *onlyDiff,
+			DeleteMissingConfigs: *deleteMissingConfigs,
+			AllowUnspecified:     *allowUnspecified,
+		})
+		if errors.Is(err, errPlanPending) {
+			exit(exitChanged)
+		}
+		if err != nil {
+			if errors.Is(err, errCanceled) {
+				fmt.Println("已取消")
+				exit(exitError)
+			}
+			fatal(err)
+		}
+
+	case "broker-config":
+		if len(os.Args) < 3 || (os.Args[2] != "get" && os.Args[2] != "set") {
+			fmt.Println("用法: kafka-topicctl broker-config <get|set> [参数]")
+			exit(exitUsage)
+		}
+		action := os.Args[2]
+
+		fs := flag.NewFlagSet("broker-config "+action, flag.ContinueOnError)
+		conn := bindConnFlags(fs)
+		var target resourceTarget
+		fs.StringVar(&target.Type, "resource-type", "broker", "配置资源类型: topic / broker / broker-logger")
+		fs.StringVar(&target.Name, "resource-name", "", "资源名称: topic 名称或 broker ID（与 --broker / --all-brokers 二选一）")
+		fs.IntVar(&target.Broker, "broker", -1, "broker ID（--resource-type 为 broker / broker-logger 时）")
+		fs.BoolVar(&target.AllBrokers, "all-brokers", false, "对集群中的全部 broker 执行（--resource-type 为 broker / broker-logger 时）")
+		fs.BoolVar(&target.ClusterDefault, "cluster-default", false, "操作集群级动态默认配置，对没有单独覆盖的全部 broker 生效（仅 --resource-type broker）")
+		configs := configFlags{}
+		if action == "set" {
+			fs.Var(configs, "set", "要修改的配置 key=value（可重复）")
+		}
+		parseArgs(fs, conn, os.Args[3:])
+
+		if len(conn.brokers()) == 0 || (action == "set" && len(configs) == 0) {
+			usage(fs)
+		}
+		if err := target.validate(); err != nil {
+			fatal(err)
+		}
+
+		var err error
+		if action == "get" {
+			err = getBrokerConfig(conn, target)
+		} else {
+			err = setBrokerConfig(conn, target, configs)
+		}
+		if err != nil {
+			fatal(err)
+		}
+
+	case "acl":
+		if len(os.Args) < 3 || (os.Args[2] != "list" && os.Args[2] != "create" && os.Args[2] != "delete") {
+			fmt.Println("用法: kafka-topicctl acl <list|create|delete> [参数]")
+			exit(exitUsage)
+		}
+		action := os.Args[2]
+
+		fs := flag.NewFlagSet("acl "+action, flag.ContinueOnError)
+		conn := bindConnFlags(fs)
+		binding := bindAclFlags(fs)
+		out := fs.String("out", "", "list: 同时把结果写入该文件（- 表示 stdout），可供 acl create --in 使用")
+		in := fs.String("in", "", "create: 从该文件批量创建 ACL")
+		yes := fs.Bool("yes", false, "delete: 跳过交互确认")
+		parseArgs(fs, conn, os.Args[3:])
+
+		if len(conn.brokers()) == 0 {
+			usage(fs)
+		}
+
+		var err error
+		switch action {
+		case "list":
+			err = listAcls(conn, *binding, *out)
+		case "create":
+			bindings := []aclBinding{*binding}
+			if *in != "" {
+				if bindings, err = loadAclFile(*in); err != nil {
+					fatal(err)
+				}
+			}
+			err = createAcls(conn, bindings)
+		case "delete":
+			err = deleteAcls(conn, *binding, *yes)
+		}
+		if errors.Is(err, errCanceled) {
+			fmt.Println("已取消")
+			exit(exitError)
+		}
+		if err != nil {
+			fatal(err)
+		}
+
+	case "compare":
+		fs := flag.NewFlagSet("compare", flag.ContinueOnError)
+		conn := bindClientFlags(fs)
+		left := fs.String("left", "", "左侧集群 bootstrap server（多个用逗号分隔）")
+		var rights clusterList
+		fs.Var(&rights, "right", "右侧集群 bootstrap server（多个 broker 用逗号分隔；可重复指定以与多个集群对比）")
+		continueOnConnectError := fs.Bool("continue-on-connect-error", false, "某个右侧集群无法连接时记录并继续对比其余集群，结束时汇总并以非零状态退出")
+		excludeInternal := fs.Bool("exclude-internal", true, "排除内部 topic（默认 true）")
+		include := fs.String("include", "", "只对比名称匹配该正则的 topic")
+		exclude := fs.String("exclude", "", "排除名称匹配该正则的 topic（在 --include 之后生效）")
+		output := fs.String("output", "text", "输出格式: text / json（json 中 removed 为仅存在于左侧，added 为仅存在于右侧）")
+		parseFlags(fs, conn)
+
+		leftConn := *conn
+		leftConn.Bootstrap = *left
+		if len(leftConn.brokers()) == 0 || len(rights) == 0 || (*output != "text" && *output != "json") {
+			usage(fs)
+		}
+
+		filter, err := topicctl.NewFilter(*excludeInternal, *include, *exclude)
+		if err != nil {
+			fatal(err)
+		}
+
+		// 左侧集群只列一次；左侧集群连接失败始终是致命错误
+		leftTopics, err := listClusterTopics(&leftConn, filter)
+		if err != nil {
+			fatal(fmt.Errorf("读取左侧集群失败: %w", err))
+		}
+
+		var unreachable []string
+		differs := false
+		diffs := make(map[string]topicctl.Diff)
+		for _, right := range rights {
+			rightConn := *conn
+			rightConn.Bootstrap = right
+			rightTopics, err := listClusterTopics(&rightConn, filter)
+			if skipUnreachable(err, *continueOnConnectError, &unreachable) {
+				continue
+			}
+			if err != nil {
+				fatal(fmt.Errorf("读取右侧集群 %s 失败: %w", right, err))
+			}
+
+			d := compareTopics(leftTopics, rightTopics)
+			differs = differs || !d.Empty()
+			switch {
+			case *output == "json":
+				diffs[right] = jsonDiff(d)
+			case len(rights) > 1:
+				fmt.Printf("🔍 %s <-> %s\n", *left, right)
+				printCompare(d, *left, right)
+				if d.Empty() {
+					fmt.Println("🎉 两个集群的 topic 一致")
+				}
+			default:
+				printCompare(d, *left, right)
+			}
+		}
+
+		// 单个右侧集群时保持原有的 JSON 结构，多个时输出 集群 -> 对比结果
+		if *output == "json" {
+			var v any = diffs
+			if len(rights) == 1 {
+				v = diffs[rights[0]]
+			}
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			if len(diffs) > 0 || len(rights) > 1 {
+				if err := enc.Encode(v); err != nil {
+					fatal(err)
+				}
+			}
+		}
+		reportUnreachable(unreachable)
+		if differs {
+			exit(exitChanged)
+		}
+		if *output == "text" && len(rights) == 1 {
+			fmt.Println("🎉 两个集群的 topic 一致")
+		}
+
+	case "normalize":
+		fs := flag.NewFlagSet("normalize", flag.ContinueOnError)
+		in := fs.String("in", "topics.json", "要整理的文件或目录（默认当前目录 topics.json，- 表示 stdin）")
+		out := fs.String("out", "", "输出文件或目录（默认覆盖 --in，- 表示 stdout）")
+		format := fs.String("format", "auto", "文件格式: json / yaml / ndjson / auto（按扩展名判断）")
+		parseOnly(fs, os.Args[2:])
+
+		if *out == "" {
+			*out = *in
+		}
+
+		file, err := topicctl.LoadFile(*in, *format)
+		if err != nil {
+			fatal(err)
+		}
+		before := len(file.Topics)
+		if file, err = topicctl.Normalize(file); err != nil {
+			fatal(err)
+		}
+
+		if info, statErr := os.Stat(*out); statErr == nil && info.IsDir() {
+			err = topicctl.WriteDir(*out, *format, file)
+		} else {
+			err = topicctl.WriteFile(*out, *format, file)
+		}
+		if err != nil {
+			fatal(err)
+		}
+		if *out != topicctl.Stdio {
+			fmt.Printf("🎉 整理完成: %s (%d 个 topic，去掉重复 %d 个)\n", *out, len(file.Topics), before-len(file.Topics))
+		}
+
+	case "schema":
+		fs := flag.NewFlagSet("schema", flag.ContinueOnError)
+		out := fs.String("out", topicctl.Stdio, "输出文件（默认 - 表示 stdout）")
+		parseOnly(fs, os.Args[2:])
+
+		// 与 --strict 校验使用的是同一份内嵌 schema
+		if *out == topicctl.Stdio {
+			if _, err := os.Stdout.Write(topicctl.Schema); err != nil {
+				fatal(err)
+			}
+			return
+		}
+		if err := os.WriteFile(*out, topicctl.Schema, 0644); err != nil {
+			fatal(err)
+		}
+		fmt.Printf("🎉 已写出 JSON Schema: %s\n", *out)
+
+	case "health":
+		fs := flag.NewFlagSet("health", flag.ContinueOnError)
+		conn := bindConnFlags(fs)
+		excludeInternal := fs.Bool("exclude-internal", true, "排除内部 topic（默认 true）")
+		include := fs.String("include", "", "只检查名称匹配该正则的 topic")
+		exclude := fs.String("exclude", "", "排除名称匹配该正则的 topic（在 --include 之后生效）")
+		parseFlags(fs, conn)
 
-		if *broker == "" {
-			fs.Usage()
-			os.Exit(1)
+		if len(conn.brokers()) == 0 {
+			usage(fs)
 		}
 
-		if err := importTopics(*broker, *in, *ifNotExists); err != nil {
-			panic(err)
+		filter, err := topicctl.NewFilter(*excludeInternal, *include, *exclude)
+		if err != nil {
+			fatal(err)
+		}
+		under, err := checkHealth(conn, filter)
+		if err != nil {
+			fatal(err)
 		}
+		if under > 0 {
+			exit(exitChanged)
+		}
+
+	case "export-offsets":
+		fs := flag.NewFlagSet("export-offsets", flag.ContinueOnError)
+		conn := bindConnFlags(fs)
+		out := fs.String("out", "offsets.json", "输出文件（默认当前目录 offsets.json）")
+		group := fs.String("group", "", "只导出指定的 group（默认全部）")
+		parseFlags(fs, conn)
+
+		if len(conn.brokers()) == 0 {
+			usage(fs)
+		}
+
+		n, err := exportOffsets(conn, *out, *group)
+		if err != nil {
+			fatal(err)
+		}
+		fmt.Printf("🎉 导出完成: %s (%d 个 group)\n", *out, n)
+
+	case "import-offsets":
+		fs := flag.NewFlagSet("import-offsets", flag.ContinueOnError)
+		conn := bindConnFlags(fs)
+		in := fs.String("in", "offsets.json", "export-offsets 导出的文件（默认当前目录 offsets.json）")
+		force := fs.Bool("force", false, "即使 group 仍有活跃成员也强制恢复")
+		parseFlags(fs, conn)
 
-		fmt.Println("🎉 导入完成")
+		if len(conn.brokers()) == 0 {
+			usage(fs)
+		}
+
+		n, err := importOffsets(conn, *in, *force)
+		if err != nil {
+			fatal(err)
+		}
+		fmt.Printf("🎉 恢复完成: %d 个 group\n", n)
+
+	case "truncate":
+		fs := flag.NewFlagSet("truncate", flag.ContinueOnError)
+		conn := bindConnFlags(fs)
+		topic := fs.String("topic", "", "要清理的 topic")
+		beforeOffset := fs.String("before-offset", "", "删除该 offset 之前的消息：单个值作用于所有分区，或 分区=offset 列表，如 0=100,1=200")
+		beforeTimestamp := fs.String("before-timestamp", "", "删除该时间之前的消息（RFC3339 或毫秒时间戳）")
+		yes := fs.Bool("yes", false, "确认执行删除（必须指定，消息删除后无法恢复）")
+		bindAllowInternal(fs, conn)
+		parseFlags(fs, conn)
+
+		if len(conn.brokers()) == 0 || *topic == "" || (*beforeOffset == "") == (*beforeTimestamp == "") {
+			fmt.Fprintln(os.Stderr, "必须指定 --topic，且 --before-offset / --before-timestamp 二选一")
+			usage(fs)
+		}
+		if !*yes {
+			fatal(errors.New("truncate 会永久删除消息，请确认后加 --yes 执行"))
+		}
+
+		if err := truncateTopic(conn, *topic, *beforeOffset, *beforeTimestamp); err != nil {
+			fatal(err)
+		}
+
+	case "elect-leaders":
+		fs := flag.NewFlagSet("elect-leaders", flag.ContinueOnError)
+		conn := bindConnFlags(fs)
+		topics := fs.String("topics", "", "要选举的 topic，逗号分隔（默认为通过 --include / --exclude 过滤的全部 topic）")
+		excludeInternal := fs.Bool("exclude-internal", true, "排除内部 topic（默认 true）")
+		include := fs.String("include", "", "只处理名称匹配该正则的 topic")
+		exclude := fs.String("exclude", "", "排除名称匹配该正则的 topic（在 --include 之后生效）")
+		electionType := fs.String("type", "preferred", "选举类型: preferred（优先副本）/ unclean（允许不在 ISR 中的副本成为 leader，可能丢数据）")
+		yes := fs.Bool("yes", false, "确认执行 unclean 选举（--type unclean 时必须指定）")
+		parseFlags(fs, conn)
+
+		if len(conn.brokers()) == 0 {
+			usage(fs)
+		}
+		typ, ok := electionTypes[*electionType]
+		if !ok {
+			fatal(usageError(fmt.Sprintf("不支持的 --type %q（可选 preferred / unclean）", *electionType)))
+		}
+		if *electionType == "unclean" && !*yes {
+			fatal(usageError("unclean 选举可能丢失已提交的消息，请确认后加 --yes 执行"))
+		}
+		names := splitList(*topics)
+		if len(names) > 0 && (*include != "" || *exclude != "") {
+			fatal(usageError("--topics 不能与 --include / --exclude 同时使用"))
+		}
+
+		filter, err := topicctl.NewFilter(*excludeInternal, *include, *exclude)
+		if err != nil {
+			fatal(err)
+		}
+		sum, err := electLeaders(conn, names, filter, typ)
+		if err == nil || sum != (electionSummary{}) {
+			fmt.Printf("📊 leader 发生变化 %d 个分区，已是最优 %d 个，失败 %d 个\n", sum.Changed, sum.Optimal, sum.Failed)
+		}
+		if err != nil {
+			fatal(partial(err, sum.Changed+sum.Optimal))
+		}
+		fmt.Println("🎉 leader 选举完成")
+
+	case "unthrottle":
+		fs := flag.NewFlagSet("unthrottle", flag.ContinueOnError)
+		conn := bindConnFlags(fs)
+		excludeInternal := fs.Bool("exclude-internal", true, "排除内部 topic（默认 true）")
+		include := fs.String("include", "", "只处理名称匹配该正则的 topic")
+		exclude := fs.String("exclude", "", "排除名称匹配该正则的 topic（在 --include 之后生效）")
+		brokers := fs.Bool("brokers", true, "同时删除各 broker 上动态设置的 *.replication.throttled.rate（默认 true）")
+		parseFlags(fs, conn)
+
+		if len(conn.brokers()) == 0 {
+			usage(fs)
+		}
+
+		filter, err := topicctl.NewFilter(*excludeInternal, *include, *exclude)
+		if err != nil {
+			fatal(err)
+		}
+		topics, brokerCount, err := unthrottle(conn, filter, *brokers)
+		if err != nil {
+			fatal(err)
+		}
+		fmt.Printf("🎉 清理完成: %d 个 topic，%d 个 broker\n", topics, brokerCount)
 
 	default:
-		fmt.Println("支持命令: export / import")
+		fmt.Println("支持命令: export / import / diff / delete / describe / validate / migrate / list / reassign / groups / create / brokers / apply / broker-config / acl / compare / normalize / schema / health / export-offsets / import-offsets / truncate / unthrottle / elect-leaders")
+		exit(exitUsage)
 	}
 }
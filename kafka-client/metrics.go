@@ -0,0 +1,42 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// metric 是一个 Prometheus gauge 的名称、说明和值
+type metric struct {
+	name, help string
+	value      float64
+}
+
+// writeMetrics 以 Prometheus 文本格式把本次运行的指标写入 path，每个指标带 command 标签，便于推送到 Pushgateway；
+// path 为空时不写
+func writeMetrics(path, command string, start time.Time, success bool, metrics ...metric) error {
+	if path == "" {
+		return nil
+	}
+
+	ok := 0.0
+	if success {
+		ok = 1
+	}
+	metrics = append(metrics,
+		metric{"kafka_topicctl_run_duration_seconds", "本次运行耗时（秒）", time.Since(start).Seconds()},
+		metric{"kafka_topicctl_run_success", "本次运行是否成功（1 成功，0 失败）", ok},
+	)
+
+	var b strings.Builder
+	for _, m := range metrics {
+		fmt.Fprintf(&b, "# HELP %s %s\n", m.name, m.help)
+		fmt.Fprintf(&b, "# TYPE %s gauge\n", m.name)
+		fmt.Fprintf(&b, "%s{command=%q} %g\n", m.name, command, m.value)
+	}
+	if err := os.WriteFile(path, []byte(b.String()), 0644); err != nil {
+		return fmt.Errorf("写入指标文件 %s 失败: %w", path, err)
+	}
+	return nil
+}
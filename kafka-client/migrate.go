@@ -0,0 +1,19 @@
+package main
+
+import (
+	"context"
+
+	"kafka-topicctl/topicctl"
+)
+
+// migrateTopics 把已从源集群列出的 topic 直接创建到目标集群，不经过中间文件；连接目标集群失败时返回 connectError
+func migrateTopics(ctx context.Context, dest *Config, topics []topicctl.Topic, opts topicctl.ImportOptions) (topicctl.Result, error) {
+	destAdmin, err := newAdmin(dest)
+	if err != nil {
+		return topicctl.Result{}, err
+	}
+	defer destAdmin.Close()
+
+	opts.Log = lg.log
+	return topicctl.ImportTopics(ctx, destAdmin, &topicctl.ExportFile{Topics: topics}, opts)
+}
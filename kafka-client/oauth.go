@@ -0,0 +1,102 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/IBM/sarama"
+)
+
+// tokenRefreshMargin 是 token 过期前提前刷新的时间，避免请求途中过期
+const tokenRefreshMargin = 30 * time.Second
+
+// oauthTokenProvider 实现 sarama.AccessTokenProvider。
+// sarama 只要求一个方法 Token() (*sarama.AccessToken, error)，每次 SASL/OAUTHBEARER 握手时调用，
+// 因此缓存和刷新都由实现方负责：这里缓存 token，在过期前按需向 token endpoint 重新申请。
+type oauthTokenProvider struct {
+	static       string // --oauth-token 指定的固定 token，非空时不请求 token endpoint
+	tokenURL     string
+	clientID     string
+	clientSecret string
+	httpClient   *http.Client
+
+	mu      sync.Mutex
+	token   string
+	expires time.Time
+}
+
+// newTokenProvider 根据 OAuth 参数创建 token provider
+func (c *Config) newTokenProvider() (*oauthTokenProvider, error) {
+	if c.OAuthToken != "" {
+		return &oauthTokenProvider{static: c.OAuthToken}, nil
+	}
+	if c.OAuthTokenURL == "" || c.OAuthClientID == "" || c.OAuthClientSecret == "" {
+		return nil, errors.New("oauthbearer 需要 --oauth-token，或同时指定 --oauth-token-url、--oauth-client-id 和 --oauth-client-secret")
+	}
+	return &oauthTokenProvider{
+		tokenURL:     c.OAuthTokenURL,
+		clientID:     c.OAuthClientID,
+		clientSecret: c.OAuthClientSecret,
+		httpClient:   &http.Client{Timeout: c.Timeout},
+	}, nil
+}
+
+// Token 返回可用的 token，缓存的 token 即将过期时重新申请
+func (p *oauthTokenProvider) Token() (*sarama.AccessToken, error) {
+	if p.static != "" {
+		return &sarama.AccessToken{Token: p.static}, nil
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.token == "" || time.Now().Add(tokenRefreshMargin).After(p.expires) {
+		if err := p.refresh(); err != nil {
+			return nil, err
+		}
+	}
+	return &sarama.AccessToken{Token: p.token}, nil
+}
+
+// refresh 用 client credentials 方式向 token endpoint 申请新 token
+func (p *oauthTokenProvider) refresh() error {
+	form := url.Values{"grant_type": {"client_credentials"}}
+	req, err := http.NewRequest(http.MethodPost, p.tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("构造 token 请求失败: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(url.QueryEscape(p.clientID), url.QueryEscape(p.clientSecret))
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("请求 token 失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("请求 token 失败: %s 返回 %s", p.tokenURL, resp.Status)
+	}
+
+	var body struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int64  `json:"expires_in"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return fmt.Errorf("解析 token 响应失败: %w", err)
+	}
+	if body.AccessToken == "" {
+		return errors.New("token 响应中没有 access_token")
+	}
+
+	p.token = body.AccessToken
+	// 未返回 expires_in 时视为立即过期，下次握手重新申请
+	p.expires = time.Now().Add(time.Duration(body.ExpiresIn) * time.Second)
+	return nil
+}
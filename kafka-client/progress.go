@@ -0,0 +1,50 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// progressBarWidth 是 TTY 进度条的字符宽度
+const progressBarWidth = 30
+
+// progress 在 stderr 上报告导入进度：终端中渲染进度条，否则每前进 10% 打印一行
+type progress struct {
+	tty     bool
+	lastPct int
+}
+
+// newProgress 创建进度报告器；stderr 是终端时使用进度条
+func newProgress() *progress {
+	p := &progress{lastPct: -1}
+	if fi, err := os.Stderr.Stat(); err == nil && fi.Mode()&os.ModeCharDevice != 0 {
+		p.tty = true
+	}
+	return p
+}
+
+// report 实现 topicctl.ImportOptions.Progress
+func (p *progress) report(done, total int) {
+	if total == 0 {
+		return
+	}
+	pct := done * 100 / total
+
+	if p.tty {
+		filled := progressBarWidth * done / total
+		bar := strings.Repeat("#", filled) + strings.Repeat(" ", progressBarWidth-filled)
+		fmt.Fprintf(os.Stderr, "\r[%s] %d/%d (%d%%)", bar, done, total, pct)
+		lg.bar = done < total
+		if done == total {
+			fmt.Fprintln(os.Stderr)
+		}
+		return
+	}
+
+	if pct/10 == p.lastPct/10 && done != total {
+		return
+	}
+	p.lastPct = pct
+	fmt.Fprintf(os.Stderr, "⏳ [%d/%d] %d%%\n", done, total, pct)
+}
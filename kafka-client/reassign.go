@@ -0,0 +1,184 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/IBM/sarama"
+)
+
+// reassignPlan 是分区重分配计划文件的结构，与 kafka-reassign-partitions.sh 的格式一致
+type reassignPlan struct {
+	Version    int                 `json:"version"`
+	Partitions []reassignPartition `json:"partitions"`
+}
+
+// reassignPartition 指定一个分区的目标副本
+type reassignPartition struct {
+	Topic     string  `json:"topic"`
+	Partition int32   `json:"partition"`
+	Replicas  []int32 `json:"replicas"`
+}
+
+// loadReassignPlan 读取并解析重分配计划
+func loadReassignPlan(path string) (*reassignPlan, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var plan reassignPlan
+	if err := json.Unmarshal(data, &plan); err != nil {
+		return nil, fmt.Errorf("解析 %s 失败: %w", path, err)
+	}
+	if len(plan.Partitions) == 0 {
+		return nil, fmt.Errorf("%s 中没有任何分区", path)
+	}
+	return &plan, nil
+}
+
+// byTopic 按 topic 分组计划中的分区
+func (p *reassignPlan) byTopic() map[string]map[int32][]int32 {
+	topics := make(map[string]map[int32][]int32)
+	for _, rp := range p.Partitions {
+		if topics[rp.Topic] == nil {
+			topics[rp.Topic] = make(map[int32][]int32)
+		}
+		topics[rp.Topic][rp.Partition] = rp.Replicas
+	}
+	return topics
+}
+
+// sortedKeys 返回按名称排序的 topic
+func sortedKeys(m map[string]map[int32][]int32) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// reassignPartitions 校验计划中的 broker 后提交分区重分配
+func reassignPartitions(conn *Config, plan *reassignPlan) error {
+	admin, err := newAdmin(conn)
+	if err != nil {
+		return err
+	}
+	defer admin.Close()
+
+	brokers, _, err := admin.DescribeCluster()
+	if err != nil {
+		return err
+	}
+	known := make(map[int32]bool, len(brokers))
+	for _, b := range brokers {
+		known[b.ID()] = true
+	}
+
+	var problems []string
+	for _, rp := range plan.Partitions {
+		if len(rp.Replicas) == 0 {
+			problems = append(problems, fmt.Sprintf("%s-%d: replicas 不能为空", rp.Topic, rp.Partition))
+		}
+		for _, id := range rp.Replicas {
+			if !known[id] {
+				problems = append(problems, fmt.Sprintf("%s-%d: broker %d 不存在", rp.Topic, rp.Partition, id))
+			}
+		}
+	}
+	if len(problems) > 0 {
+		return errors.New("重分配计划校验失败:\n  " + strings.Join(problems, "\n  "))
+	}
+
+	topics := plan.byTopic()
+	for _, topic := range sortedKeys(topics) {
+		assignment, err := fullAssignment(admin, topic, topics[topic])
+		if err != nil {
+			return err
+		}
+		if err := admin.AlterPartitionReassignments(topic, assignment); err != nil {
+			return fmt.Errorf("提交 topic %s 的重分配失败: %w", topic, err)
+		}
+		fmt.Printf("🔀 已提交重分配: %s (%d 个分区)\n", topic, len(topics[topic]))
+	}
+	return nil
+}
+
+// fullAssignment 构造从 0 开始连续的分区副本列表；
+// sarama 会把缺失的分区当作"取消重分配"提交，因此计划未覆盖的分区沿用当前副本
+func fullAssignment(admin sarama.ClusterAdmin, topic string, planned map[int32][]int32) ([][]int32, error) {
+	metas, err := admin.DescribeTopics([]string{topic})
+	if err != nil {
+		return nil, err
+	}
+	if len(metas) == 0 || metas[0].Err != sarama.ErrNoError {
+		return nil, fmt.Errorf("topic 不存在: %s", topic)
+	}
+
+	current := make(map[int32][]int32, len(metas[0].Partitions))
+	for _, p := range metas[0].Partitions {
+		current[p.ID] = p.Replicas
+	}
+
+	var maxID int32 = -1
+	for id := range planned {
+		if _, ok := current[id]; !ok {
+			return nil, fmt.Errorf("topic %s 没有分区 %d", topic, id)
+		}
+		if id > maxID {
+			maxID = id
+		}
+	}
+
+	assignment := make([][]int32, maxID+1)
+	for id := int32(0); id <= maxID; id++ {
+		if replicas, ok := planned[id]; ok {
+			assignment[id] = replicas
+		} else {
+			assignment[id] = current[id]
+		}
+	}
+	return assignment, nil
+}
+
+// waitReassignments 轮询计划中分区的重分配进度，直到全部完成
+func waitReassignments(conn *Config, plan *reassignPlan, interval time.Duration) error {
+	admin, err := newAdmin(conn)
+	if err != nil {
+		return err
+	}
+	defer admin.Close()
+
+	topics := plan.byTopic()
+	total := len(plan.Partitions)
+	for {
+		pending := 0
+		for _, topic := range sortedKeys(topics) {
+			partitions := make([]int32, 0, len(topics[topic]))
+			for id := range topics[topic] {
+				partitions = append(partitions, id)
+			}
+
+			status, err := admin.ListPartitionReassignments(topic, partitions)
+			if err != nil {
+				return err
+			}
+			for id, s := range status[topic] {
+				pending++
+				fmt.Printf("⏳ %s-%d: adding=%v removing=%v\n", topic, id, s.AddingReplicas, s.RemovingReplicas)
+			}
+		}
+
+		fmt.Printf("📊 重分配进度: %d/%d 个分区已完成\n", total-pending, total)
+		if pending == 0 {
+			return nil
+		}
+		time.Sleep(interval)
+	}
+}
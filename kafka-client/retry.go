@@ -0,0 +1,83 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/IBM/sarama"
+
+	"kafka-topicctl/topicctl"
+)
+
+// retriableErrors 是值得重试的瞬时错误
+var retriableErrors = []error{
+	sarama.ErrOutOfBrokers,
+	sarama.ErrControllerNotAvailable,
+	sarama.ErrRequestTimedOut,
+	sarama.ErrBrokerNotAvailable,
+	sarama.ErrLeaderNotAvailable,
+	sarama.ErrNotController,
+	sarama.ErrNetworkException,
+	sarama.ErrNotEnoughReplicas,
+}
+
+// isRetriable 判断错误是否为瞬时错误；ErrTopicAlreadyExists 等终态错误不重试
+func isRetriable(err error) bool {
+	for _, target := range retriableErrors {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// retryAdmin 包装 sarama.ClusterAdmin，对 ListTopics/CreateTopic 的瞬时错误按指数退避重试
+type retryAdmin struct {
+	sarama.ClusterAdmin
+	retries int
+	backoff time.Duration
+}
+
+// ListTopics 带重试的 ListTopics
+func (a *retryAdmin) ListTopics() (map[string]sarama.TopicDetail, error) {
+	var topics map[string]sarama.TopicDetail
+	err := a.do("ListTopics", func() (err error) {
+		topics, err = a.ClusterAdmin.ListTopics()
+		return err
+	})
+	return topics, err
+}
+
+// CreateTopic 带重试的 CreateTopic
+func (a *retryAdmin) CreateTopic(topic string, detail *sarama.TopicDetail, validateOnly bool) error {
+	return a.do("CreateTopic "+topic, func() error {
+		return a.ClusterAdmin.CreateTopic(topic, detail, validateOnly)
+	})
+}
+
+// do 执行 fn，遇到瞬时错误时最多重试 retries 次，每次等待时间翻倍
+func (a *retryAdmin) do(op string, fn func() error) error {
+	backoff := a.backoff
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil || !isRetriable(err) {
+			return err
+		}
+		if attempt > a.retries {
+			return fmt.Errorf("%w（%d 次）: %w", topicctl.ErrRetriesExhausted, a.retries, err)
+		}
+		// 重试提示属于过程信息，--quiet 时不输出；写到 stderr 以免混进 stdout 的导出数据
+		if !lg.quiet {
+			lg.logStderr(topicctl.Event{
+				Action: "retry", Status: "warning", Error: err.Error(),
+				Detail:  fmt.Sprintf("op=%s, attempt=%d/%d, backoff=%s", op, attempt, a.retries, backoff),
+				Message: fmt.Sprintf("🔁 %s 失败: %v，%s 后重试 (%d/%d)", op, err, backoff, attempt, a.retries),
+			})
+		}
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
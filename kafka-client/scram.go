@@ -0,0 +1,42 @@
+package main
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+
+	"github.com/xdg-go/scram"
+)
+
+var (
+	// SHA256 是 SCRAM-SHA-256 使用的哈希
+	SHA256 scram.HashGeneratorFcn = sha256.New
+	// SHA512 是 SCRAM-SHA-512 使用的哈希
+	SHA512 scram.HashGeneratorFcn = sha512.New
+)
+
+// XDGSCRAMClient 用 xdg-go/scram 实现 sarama.SCRAMClient
+type XDGSCRAMClient struct {
+	*scram.Client
+	*scram.ClientConversation
+	scram.HashGeneratorFcn
+}
+
+// Begin 开始一次 SCRAM 会话
+func (x *XDGSCRAMClient) Begin(userName, password, authzID string) (err error) {
+	x.Client, err = x.HashGeneratorFcn.NewClient(userName, password, authzID)
+	if err != nil {
+		return err
+	}
+	x.ClientConversation = x.Client.NewConversation()
+	return nil
+}
+
+// Step 处理服务端的 challenge 并返回响应
+func (x *XDGSCRAMClient) Step(challenge string) (response string, err error) {
+	return x.ClientConversation.Step(challenge)
+}
+
+// Done 判断会话是否结束
+func (x *XDGSCRAMClient) Done() bool {
+	return x.ClientConversation.Done()
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// lookupBootstrapSRV 解析 SRV 记录（如 _kafka._tcp.example.com），按优先级升序、同优先级按权重降序返回 host:port 列表
+func lookupBootstrapSRV(name string, timeout time.Duration) ([]string, error) {
+	ctx := context.Background()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	_, records, err := net.DefaultResolver.LookupSRV(ctx, "", "", name)
+	if err != nil {
+		return nil, fmt.Errorf("解析 SRV 记录 %s 失败: %w", name, err)
+	}
+
+	sort.SliceStable(records, func(i, j int) bool {
+		if records[i].Priority != records[j].Priority {
+			return records[i].Priority < records[j].Priority
+		}
+		return records[i].Weight > records[j].Weight
+	})
+
+	var brokers []string
+	for _, r := range records {
+		// RFC 2782: 目标为 "." 表示该服务不可用
+		host := strings.TrimSuffix(r.Target, ".")
+		if host == "" {
+			continue
+		}
+		brokers = append(brokers, net.JoinHostPort(host, strconv.Itoa(int(r.Port))))
+	}
+	if len(brokers) == 0 {
+		return nil, fmt.Errorf("SRV 记录 %s 没有可用的目标", name)
+	}
+	return brokers, nil
+}
+
+// resolveBootstrapSRV 指定了 --bootstrap-srv 时解析 SRV 记录并填充 Bootstrap；
+// 与显式指定的 --bootstrap（命令行或配置文件）同时使用时返回 usageError，环境变量 KAFKA_BOOTSTRAP 则被覆盖
+func (c *Config) resolveBootstrapSRV(explicitBootstrap bool) error {
+	if c.BootstrapSRV == "" {
+		return nil
+	}
+	if explicitBootstrap {
+		return usageError("--bootstrap 和 --bootstrap-srv 不能同时指定")
+	}
+	brokers, err := lookupBootstrapSRV(c.BootstrapSRV, c.Timeout)
+	if err != nil {
+		return &connectError{Cluster: c.BootstrapSRV, Err: err}
+	}
+	c.Bootstrap = strings.Join(brokers, ",")
+	if c.Debug {
+		fmt.Fprintf(os.Stderr, "🔎 SRV 记录 %s 解析出 %d 个 broker: %s\n", c.BootstrapSRV, len(brokers), c.Bootstrap)
+	}
+	return nil
+}
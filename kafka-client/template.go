@@ -0,0 +1,69 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+
+	"kafka-topicctl/topicctl"
+)
+
+// templateHelp 是 list / describe 的 --template 参数说明
+const templateHelp = "按 Go text/template 输出每个 topic，如 '{{.Name}} {{.Partitions}} {{.ReplicationFactor}}'；预设: names / wide"
+
+// templatePresets 是 --template 的内置预设，header 非空时在结果前输出一行表头
+var templatePresets = map[string]struct{ header, text string }{
+	"names": {text: "{{.Name}}"},
+	"wide": {
+		header: "NAME\tPARTITIONS\tREPLICATION FACTOR\tRETENTION.MS\tCLEANUP.POLICY\tCONFIGS",
+		text:   `{{.Name}}	{{.Partitions}}	{{.ReplicationFactor}}	{{or (index .Configs "retention.ms") "-"}}	{{or (index .Configs "cleanup.policy") "-"}}	{{len .Configs}}`,
+	},
+}
+
+// topicTemplate 是解析后的 --template
+type topicTemplate struct {
+	header string
+	tmpl   *template.Template
+}
+
+// parseTopicTemplate 解析 --template：预设名（names / wide）或对每个 topic 执行的 Go text/template，
+// 字段与 topicctl.Topic 相同，如 {{.Name}} {{.Partitions}} {{index .Configs "retention.ms"}}；空串返回 nil
+func parseTopicTemplate(s string) (*topicTemplate, error) {
+	if s == "" {
+		return nil, nil
+	}
+	text, header := s, ""
+	if p, ok := templatePresets[s]; ok {
+		text, header = p.text, p.header
+	}
+	tmpl, err := template.New("topic").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("解析 --template 失败: %w", err)
+	}
+	return &topicTemplate{header: header, tmpl: tmpl}, nil
+}
+
+// render 对每个 topic 执行模板，每个 topic 一行；结果中以制表符分隔的列按 tabwriter 对齐
+func (t *topicTemplate) render(out io.Writer, topics []topicctl.Topic) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	if t.header != "" {
+		fmt.Fprintln(w, t.header)
+	}
+	var line strings.Builder
+	for _, topic := range topics {
+		line.Reset()
+		if err := t.tmpl.Execute(&line, topic); err != nil {
+			return fmt.Errorf("topic %s 执行 --template 失败: %w", topic.Name, err)
+		}
+		s := line.String()
+		if !strings.HasSuffix(s, "\n") {
+			s += "\n"
+		}
+		if _, err := io.WriteString(w, s); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/IBM/sarama"
+
+	"kafka-topicctl/topicctl"
+)
+
+// timeoutAdmin 包装 sarama.ClusterAdmin，给 ListTopics 加上整体超时，每次成功返回后在 stderr 记录拉取到的 topic 数和耗时
+type timeoutAdmin struct {
+	sarama.ClusterAdmin
+	timeout time.Duration
+}
+
+// ListTopics 在 timeout 内等待 ListTopics 返回，超时则返回明确的超时错误
+func (a *timeoutAdmin) ListTopics() (map[string]sarama.TopicDetail, error) {
+	type result struct {
+		topics map[string]sarama.TopicDetail
+		err    error
+	}
+	start := time.Now()
+	done := make(chan result, 1)
+	go func() {
+		topics, err := a.ClusterAdmin.ListTopics()
+		done <- result{topics, err}
+	}()
+
+	select {
+	case r := <-done:
+		if r.err == nil {
+			elapsed := time.Since(start).Round(time.Millisecond)
+			lg.logStderr(topicctl.Event{
+				Action: "list-topics", Status: "done", Detail: fmt.Sprintf("topics=%d, duration=%s", len(r.topics), elapsed),
+				Message: fmt.Sprintf("📋 ListTopics 返回 %d 个 topic，耗时 %s", len(r.topics), elapsed),
+			})
+		}
+		return r.topics, r.err
+	case <-time.After(a.timeout):
+		return nil, fmt.Errorf("ListTopics 超时（%s）", a.timeout)
+	}
+}
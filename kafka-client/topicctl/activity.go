@@ -0,0 +1,34 @@
+package topicctl
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/IBM/sarama"
+)
+
+// ActiveTopics 只保留 since 之后有写入的 topic：对每个分区按时间戳查询 offset，
+// 任一分区存在时间戳不早于 since 的消息即视为活跃；没有带时间戳消息的 topic 会被剔除
+func ActiveTopics(client sarama.Client, topics []Topic, since time.Time) ([]Topic, error) {
+	ts := since.UnixMilli()
+
+	var active []Topic
+	for _, t := range topics {
+		partitions, err := client.Partitions(t.Name)
+		if err != nil {
+			return nil, fmt.Errorf("查询 topic %s 的分区失败: %w", t.Name, err)
+		}
+		for _, p := range partitions {
+			// 没有时间戳不早于 ts 的消息时 broker 返回 -1
+			offset, err := client.GetOffset(t.Name, p, ts)
+			if err != nil {
+				return nil, fmt.Errorf("查询 %s-%d 的 offset 失败: %w", t.Name, p, err)
+			}
+			if offset >= 0 {
+				active = append(active, t)
+				break
+			}
+		}
+	}
+	return active, nil
+}
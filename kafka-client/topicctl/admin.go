@@ -0,0 +1,19 @@
+package topicctl
+
+import "github.com/IBM/sarama"
+
+// Admin 是本包用到的 sarama.ClusterAdmin 方法子集；sarama.ClusterAdmin 天然满足它，
+// 调用方也可以传入只实现这些方法的替身（如 admintest.Admin），在没有 broker 的情况下驱动导出和导入逻辑
+type Admin interface {
+	ListTopics() (map[string]sarama.TopicDetail, error)
+	CreateTopic(topic string, detail *sarama.TopicDetail, validateOnly bool) error
+	CreatePartitions(topic string, count int32, assignment [][]int32, validateOnly bool) error
+	DescribeConfig(resource sarama.ConfigResource) ([]sarama.ConfigEntry, error)
+	DescribeTopics(topics []string) ([]*sarama.TopicMetadata, error) // 仅 --include-partition-detail 时使用
+	IncrementalAlterConfig(resourceType sarama.ConfigResourceType, name string, entries map[string]sarama.IncrementalAlterConfigsEntry, validateOnly bool) error
+	DescribeCluster() (brokers []*sarama.Broker, controllerID int32, err error)
+	Controller() (*sarama.Broker, error) // 仅 ImportOptions.BatchSize 批量创建时使用
+}
+
+// 编译期检查 sarama.ClusterAdmin 满足 Admin
+var _ Admin = sarama.ClusterAdmin(nil)
@@ -0,0 +1,261 @@
+// Package admintest 提供 topicctl.Admin 的内存实现，用于在没有 broker 的情况下测试导出和导入逻辑。
+package admintest
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/IBM/sarama"
+
+	"kafka-topicctl/topicctl"
+)
+
+// 编译期检查 *Admin 满足 topicctl.Admin
+var _ topicctl.Admin = (*Admin)(nil)
+
+// ErrNoController 表示内存实现不支持直接连接 controller（ImportOptions.BatchSize 批量创建）
+var ErrNoController = errors.New("admintest: 不支持连接 controller")
+
+// Admin 是保存在内存中的集群：topic 的分区数、副本数和配置覆盖项。
+// 创建、扩容和修改配置会更新内存状态，并按调用顺序记录在 Calls 中；validateOnly 的请求只校验不修改。
+// 并发调用是安全的
+type Admin struct {
+	mu      sync.Mutex
+	topics  map[string]sarama.TopicDetail
+	brokers int
+	calls   []string
+}
+
+// New 用给定的 topic 创建内存集群，broker 数为 1；topics 会被复制，之后的修改不影响调用方
+func New(topics map[string]sarama.TopicDetail) *Admin {
+	a := &Admin{topics: make(map[string]sarama.TopicDetail, len(topics)), brokers: 1}
+	for name, d := range topics {
+		a.topics[name] = copyDetail(d)
+	}
+	return a
+}
+
+// SetBrokers 设置 DescribeCluster 返回的 broker 数
+func (a *Admin) SetBrokers(n int) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.brokers = n
+}
+
+// Topic 返回 topic 当前的状态
+func (a *Admin) Topic(name string) (sarama.TopicDetail, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	d, ok := a.topics[name]
+	if !ok {
+		return sarama.TopicDetail{}, false
+	}
+	return copyDetail(d), true
+}
+
+// Calls 返回按调用顺序记录的修改请求，如 "CreateTopic orders"、"CreatePartitions orders 6"，不含只读请求和 validateOnly 请求
+func (a *Admin) Calls() []string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return append([]string(nil), a.calls...)
+}
+
+// ListTopics 实现 topicctl.Admin
+func (a *Admin) ListTopics() (map[string]sarama.TopicDetail, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	out := make(map[string]sarama.TopicDetail, len(a.topics))
+	for name, d := range a.topics {
+		out[name] = copyDetail(d)
+	}
+	return out, nil
+}
+
+// CreateTopic 实现 topicctl.Admin；NumPartitions / ReplicationFactor 为 -1 时按 1 创建，指定了 ReplicaAssignment 时以它为准
+func (a *Admin) CreateTopic(topic string, detail *sarama.TopicDetail, validateOnly bool) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if _, ok := a.topics[topic]; ok {
+		return sarama.ErrTopicAlreadyExists
+	}
+
+	d := copyDetail(*detail)
+	if len(d.ReplicaAssignment) > 0 {
+		d.NumPartitions = int32(len(d.ReplicaAssignment))
+		d.ReplicationFactor = int16(len(d.ReplicaAssignment[0]))
+	}
+	if d.NumPartitions == -1 {
+		d.NumPartitions = 1
+	}
+	if d.ReplicationFactor == -1 {
+		d.ReplicationFactor = 1
+	}
+	if d.NumPartitions < 1 {
+		return sarama.ErrInvalidPartitions
+	}
+	if d.ReplicationFactor < 1 || int(d.ReplicationFactor) > a.brokers {
+		return sarama.ErrInvalidReplicationFactor
+	}
+	if validateOnly {
+		return nil
+	}
+	a.topics[topic] = d
+	a.calls = append(a.calls, "CreateTopic "+topic)
+	return nil
+}
+
+// CreatePartitions 实现 topicctl.Admin；count 必须大于当前分区数
+func (a *Admin) CreatePartitions(topic string, count int32, assignment [][]int32, validateOnly bool) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	d, ok := a.topics[topic]
+	if !ok {
+		return sarama.ErrUnknownTopicOrPartition
+	}
+	if count <= d.NumPartitions {
+		return sarama.ErrInvalidPartitions
+	}
+	if assignment != nil && int32(len(assignment)) != count-d.NumPartitions {
+		return sarama.ErrInvalidReplicaAssignment
+	}
+	if validateOnly {
+		return nil
+	}
+	d.NumPartitions = count
+	a.topics[topic] = d
+	a.calls = append(a.calls, fmt.Sprintf("CreatePartitions %s %d", topic, count))
+	return nil
+}
+
+// DescribeConfig 实现 topicctl.Admin；只支持 topic 资源，返回的配置项都是 topic 级覆盖
+func (a *Admin) DescribeConfig(resource sarama.ConfigResource) ([]sarama.ConfigEntry, error) {
+	if resource.Type != sarama.TopicResource {
+		return nil, fmt.Errorf("admintest: 不支持的资源类型 %v", resource.Type)
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	d, ok := a.topics[resource.Name]
+	if !ok {
+		return nil, sarama.ErrUnknownTopicOrPartition
+	}
+
+	entries := make([]sarama.ConfigEntry, 0, len(d.ConfigEntries))
+	for k, v := range d.ConfigEntries {
+		e := sarama.ConfigEntry{Name: k, Source: sarama.SourceTopic}
+		if v != nil {
+			e.Value = *v
+		}
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name < entries[j].Name })
+	return entries, nil
+}
+
+// DescribeTopics 实现 topicctl.Admin；分区 i 的副本依次落在 broker i, i+1, ...（按 broker 数取模），第一个副本为 leader，全部在 ISR 中
+func (a *Admin) DescribeTopics(topics []string) ([]*sarama.TopicMetadata, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	metas := make([]*sarama.TopicMetadata, 0, len(topics))
+	for _, name := range topics {
+		d, ok := a.topics[name]
+		if !ok {
+			metas = append(metas, &sarama.TopicMetadata{Name: name, Err: sarama.ErrUnknownTopicOrPartition})
+			continue
+		}
+		meta := &sarama.TopicMetadata{Name: name}
+		for p := int32(0); p < d.NumPartitions; p++ {
+			replicas := make([]int32, d.ReplicationFactor)
+			for r := range replicas {
+				replicas[r] = (p + int32(r)) % int32(a.brokers)
+			}
+			meta.Partitions = append(meta.Partitions, &sarama.PartitionMetadata{
+				ID: p, Leader: replicas[0], Replicas: replicas, Isr: append([]int32(nil), replicas...),
+			})
+		}
+		metas = append(metas, meta)
+	}
+	return metas, nil
+}
+
+// IncrementalAlterConfig 实现 topicctl.Admin；只支持 topic 资源的 SET 和 DELETE
+func (a *Admin) IncrementalAlterConfig(resourceType sarama.ConfigResourceType, name string, entries map[string]sarama.IncrementalAlterConfigsEntry, validateOnly bool) error {
+	if resourceType != sarama.TopicResource {
+		return fmt.Errorf("admintest: 不支持的资源类型 %v", resourceType)
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	d, ok := a.topics[name]
+	if !ok {
+		return sarama.ErrUnknownTopicOrPartition
+	}
+	for k, e := range entries {
+		if e.Operation != sarama.IncrementalAlterConfigsOperationSet && e.Operation != sarama.IncrementalAlterConfigsOperationDelete {
+			return fmt.Errorf("admintest: 配置 %s 不支持的操作 %v", k, e.Operation)
+		}
+		if e.Operation == sarama.IncrementalAlterConfigsOperationSet && e.Value == nil {
+			return fmt.Errorf("admintest: 配置 %s 的 SET 操作缺少值: %w", k, sarama.ErrInvalidConfig)
+		}
+	}
+	if validateOnly {
+		return nil
+	}
+
+	if d.ConfigEntries == nil {
+		d.ConfigEntries = make(map[string]*string)
+	}
+	keys := make([]string, 0, len(entries))
+	for k, e := range entries {
+		keys = append(keys, k)
+		if e.Operation == sarama.IncrementalAlterConfigsOperationDelete {
+			delete(d.ConfigEntries, k)
+			continue
+		}
+		v := *e.Value
+		d.ConfigEntries[k] = &v
+	}
+	a.topics[name] = d
+	sort.Strings(keys)
+	a.calls = append(a.calls, fmt.Sprintf("IncrementalAlterConfig %s %v", name, keys))
+	return nil
+}
+
+// DescribeCluster 实现 topicctl.Admin；返回 SetBrokers 指定数量的 broker，controller 为 0
+func (a *Admin) DescribeCluster() ([]*sarama.Broker, int32, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	brokers := make([]*sarama.Broker, a.brokers)
+	for i := range brokers {
+		brokers[i] = sarama.NewBroker(fmt.Sprintf("broker-%d:9092", i))
+	}
+	return brokers, 0, nil
+}
+
+// Controller 实现 topicctl.Admin；内存集群没有可连接的 controller，总是返回 ErrNoController
+func (a *Admin) Controller() (*sarama.Broker, error) {
+	return nil, ErrNoController
+}
+
+// copyDetail 深拷贝 TopicDetail，避免调用方与内存状态共享 map 和指针
+func copyDetail(d sarama.TopicDetail) sarama.TopicDetail {
+	out := sarama.TopicDetail{NumPartitions: d.NumPartitions, ReplicationFactor: d.ReplicationFactor}
+	if d.ConfigEntries != nil {
+		out.ConfigEntries = make(map[string]*string, len(d.ConfigEntries))
+		for k, v := range d.ConfigEntries {
+			if v != nil {
+				v := *v
+				out.ConfigEntries[k] = &v
+			} else {
+				out.ConfigEntries[k] = nil
+			}
+		}
+	}
+	if d.ReplicaAssignment != nil {
+		out.ReplicaAssignment = make(map[int32][]int32, len(d.ReplicaAssignment))
+		for p, r := range d.ReplicaAssignment {
+			out.ReplicaAssignment[p] = append([]int32(nil), r...)
+		}
+	}
+	return out
+}
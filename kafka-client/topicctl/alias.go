@@ -0,0 +1,128 @@
+package topicctl
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// valueKind 决定别名的值如何换算
+type valueKind int
+
+const (
+	plainValue    valueKind = iota // 原样使用
+	durationValue                  // 7d、12h 等换算为毫秒
+	sizeValue                      // 500mb 等换算为字节
+)
+
+// configAlias 是简写配置项对应的 Kafka 配置项及其值的换算方式
+type configAlias struct {
+	key  string
+	kind valueKind
+}
+
+// configAliases 是内置的配置项别名表
+var configAliases = map[string]configAlias{
+	"retention":        {"retention.ms", durationValue},
+	"retention.size":   {"retention.bytes", sizeValue},
+	"segment.size":     {"segment.bytes", sizeValue},
+	"segment.roll":     {"segment.ms", durationValue},
+	"max.message.size": {"max.message.bytes", sizeValue},
+	"delete.retention": {"delete.retention.ms", durationValue},
+	"compaction.lag":   {"min.compaction.lag.ms", durationValue},
+	"min.isr":          {"min.insync.replicas", plainValue},
+	"compression":      {"compression.type", plainValue},
+	"cleanup":          {"cleanup.policy", plainValue},
+}
+
+// unitValue 匹配带单位的数值，如 7d、1.5h、500mb
+var unitValue = regexp.MustCompile(`^([0-9]+(?:\.[0-9]+)?)\s*([a-zA-Z]+)$`)
+
+// durationUnits 是时长单位对应的毫秒数
+var durationUnits = map[string]float64{
+	"ms": 1,
+	"s":  1000,
+	"m":  60 * 1000,
+	"h":  60 * 60 * 1000,
+	"d":  24 * 60 * 60 * 1000,
+	"w":  7 * 24 * 60 * 60 * 1000,
+}
+
+// sizeUnits 是容量单位对应的字节数（按 1024 进位）
+var sizeUnits = map[string]float64{
+	"b":  1,
+	"kb": 1 << 10,
+	"mb": 1 << 20,
+	"gb": 1 << 30,
+	"tb": 1 << 40,
+}
+
+// ExpandAliases 把每个 topic 配置中的简写别名（如 retention=7d）展开为 Kafka 配置项（retention.ms=604800000）；
+// 以 .ms / .bytes 结尾的 Kafka 配置项的值也可以带单位。不认识的配置项原样保留，
+// 别名与其对应的配置项同时出现且值不同时返回错误
+func ExpandAliases(file *ExportFile) error {
+	var errs []error
+	for i := range file.Topics {
+		t := &file.Topics[i]
+		if len(t.Configs) == 0 {
+			continue
+		}
+		expanded := make(map[string]string, len(t.Configs))
+		from := make(map[string]string, len(t.Configs)) // 展开后的 key -> 文件中的原始 key
+		for k, v := range t.Configs {
+			key, value, err := expandAlias(k, v)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s 配置 %s: %w", t.Name, k, err))
+				continue
+			}
+			if prev, ok := expanded[key]; ok && prev != value {
+				errs = append(errs, fmt.Errorf("%s 配置 %s 与 %s 冲突（%s / %s）", t.Name, from[key], k, prev, value))
+				continue
+			}
+			expanded[key], from[key] = value, k
+		}
+		t.Configs = expanded
+	}
+	return errors.Join(errs...)
+}
+
+// expandAlias 展开单个配置项；不认识的 key 和不带单位的值原样返回
+func expandAlias(key, value string) (string, string, error) {
+	kind := plainValue
+	if a, ok := configAliases[key]; ok {
+		key, kind = a.key, a.kind
+	} else if strings.HasSuffix(key, ".ms") {
+		kind = durationValue
+	} else if strings.HasSuffix(key, ".bytes") {
+		kind = sizeValue
+	}
+
+	switch kind {
+	case durationValue:
+		v, err := convertUnit(value, durationUnits)
+		return key, v, err
+	case sizeValue:
+		v, err := convertUnit(value, sizeUnits)
+		return key, v, err
+	}
+	return key, value, nil
+}
+
+// convertUnit 把带单位的数值换算为整数；不带单位的值（含 -1）原样返回
+func convertUnit(value string, units map[string]float64) (string, error) {
+	m := unitValue.FindStringSubmatch(strings.TrimSpace(value))
+	if m == nil {
+		return value, nil
+	}
+	factor, ok := units[strings.ToLower(m[2])]
+	if !ok {
+		return "", fmt.Errorf("无法识别 %q 的单位 %s", value, m[2])
+	}
+	n, err := strconv.ParseFloat(m[1], 64)
+	if err != nil {
+		return "", fmt.Errorf("无法解析 %q: %w", value, err)
+	}
+	return strconv.FormatInt(int64(n*factor), 10), nil
+}
@@ -0,0 +1,53 @@
+package topicctl
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// baseConfigFile 是 --base-config 文件的结构，只包含公共配置
+type baseConfigFile struct {
+	Configs map[string]string `json:"configs" yaml:"configs"`
+}
+
+// LoadBaseConfig 读取公共配置文件，格式为 {"configs": {...}}
+func LoadBaseConfig(in, format string) (map[string]string, error) {
+	var base baseConfigFile
+	if err := decodeFile(in, format, &base); err != nil {
+		return nil, err
+	}
+	return base.Configs, nil
+}
+
+// MergeBaseConfig 把 base 合并到每个 topic 的 Configs 之下，topic 自身的配置优先；
+// log 非 nil 时对每个 topic 报告从 base 继承了哪些配置项
+func MergeBaseConfig(file *ExportFile, base map[string]string, log func(Event)) {
+	if len(base) == 0 {
+		return
+	}
+	for i := range file.Topics {
+		t := &file.Topics[i]
+		merged := make(map[string]string, len(base)+len(t.Configs))
+		var inherited []string
+		for k, v := range base {
+			if _, ok := t.Configs[k]; !ok {
+				inherited = append(inherited, k)
+			}
+			merged[k] = v
+		}
+		for k, v := range t.Configs {
+			merged[k] = v
+		}
+		t.Configs = merged
+
+		if log != nil {
+			sort.Strings(inherited)
+			detail := strings.Join(inherited, ",")
+			log(Event{
+				Action: "base-config", Topic: t.Name, Status: "debug", Detail: detail,
+				Message: fmt.Sprintf("🔧 topic %s 继承公共配置 %d 项: %s", t.Name, len(inherited), detail),
+			})
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package topicctl
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// 支持的压缩方式
+const (
+	CompressNone = ""
+	CompressGzip = "gzip"
+	CompressZstd = "zstd"
+)
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+// CompressExt 返回压缩方式对应的文件扩展名
+func CompressExt(method string) (string, error) {
+	switch method {
+	case CompressNone:
+		return "", nil
+	case CompressGzip:
+		return ".gz", nil
+	case CompressZstd:
+		return ".zst", nil
+	}
+	return "", fmt.Errorf("不支持的压缩方式 %q（可选 gzip / zstd）", method)
+}
+
+// compressionFor 按扩展名判断压缩方式
+func compressionFor(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".gz":
+		return CompressGzip
+	case ".zst", ".zstd":
+		return CompressZstd
+	}
+	return CompressNone
+}
+
+// trimCompressExt 去掉压缩扩展名，便于按内层扩展名判断 json / yaml
+func trimCompressExt(path string) string {
+	if compressionFor(path) != CompressNone {
+		return strings.TrimSuffix(path, filepath.Ext(path))
+	}
+	return path
+}
+
+// compress 按压缩方式压缩数据
+func compress(method string, data []byte) ([]byte, error) {
+	switch method {
+	case CompressNone:
+		return data, nil
+	case CompressGzip:
+		var buf bytes.Buffer
+		w := gzip.NewWriter(&buf)
+		if _, err := w.Write(data); err != nil {
+			return nil, err
+		}
+		if err := w.Close(); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	case CompressZstd:
+		w, err := zstd.NewWriter(nil)
+		if err != nil {
+			return nil, err
+		}
+		defer w.Close()
+		return w.EncodeAll(data, nil), nil
+	}
+	return nil, fmt.Errorf("不支持的压缩方式 %q（可选 gzip / zstd）", method)
+}
+
+// decompress 按 magic bytes 识别 gzip / zstd 并解压，未压缩的数据原样返回
+func decompress(data []byte) ([]byte, error) {
+	switch {
+	case bytes.HasPrefix(data, gzipMagic):
+		r, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("gzip 解压失败: %w", err)
+		}
+		defer r.Close()
+		out, err := io.ReadAll(r)
+		if err != nil {
+			return nil, fmt.Errorf("gzip 解压失败: %w", err)
+		}
+		return out, nil
+	case bytes.HasPrefix(data, zstdMagic):
+		r, err := zstd.NewReader(nil)
+		if err != nil {
+			return nil, err
+		}
+		defer r.Close()
+		out, err := r.DecodeAll(data, nil)
+		if err != nil {
+			return nil, fmt.Errorf("zstd 解压失败: %w", err)
+		}
+		return out, nil
+	}
+	return data, nil
+}
@@ -0,0 +1,109 @@
+package topicctl
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/IBM/sarama"
+)
+
+// knownTopicConfigs 是 Kafka 文档列出的 topic 级配置项，集群中没有可用于查询的 topic 时使用
+var knownTopicConfigs = []string{
+	"cleanup.policy", "compression.type", "compression.gzip.level", "compression.lz4.level", "compression.zstd.level",
+	"delete.retention.ms", "file.delete.delay.ms", "flush.messages", "flush.ms",
+	"follower.replication.throttled.replicas", "leader.replication.throttled.replicas",
+	"index.interval.bytes", "local.retention.bytes", "local.retention.ms",
+	"max.compaction.lag.ms", "max.message.bytes", "message.downconversion.enable", "message.format.version",
+	"message.timestamp.after.max.ms", "message.timestamp.before.max.ms", "message.timestamp.difference.max.ms",
+	"message.timestamp.type", "min.cleanable.dirty.ratio", "min.compaction.lag.ms", "min.insync.replicas",
+	"preallocate", "remote.storage.enable", "remote.log.copy.disable", "remote.log.delete.on.disable",
+	"retention.bytes", "retention.ms", "segment.bytes", "segment.index.bytes", "segment.jitter.ms", "segment.ms",
+	"unclean.leader.election.enable",
+}
+
+// KnownTopicConfigs 返回 broker 认识的 topic 配置项：对集群中任意一个已有 topic 调用 DescribeConfig，
+// 其结果包含全部配置项（含默认值）；集群中没有 topic 时退回 knownTopicConfigs
+func KnownTopicConfigs(admin Admin) (map[string]bool, error) {
+	topics, err := admin.ListTopics()
+	if err != nil {
+		return nil, err
+	}
+
+	known := make(map[string]bool)
+	if len(topics) == 0 {
+		for _, k := range knownTopicConfigs {
+			known[k] = true
+		}
+		return known, nil
+	}
+
+	// 取名称最小的 topic，结果稳定
+	var name string
+	for n := range topics {
+		if name == "" || n < name {
+			name = n
+		}
+	}
+	entries, err := admin.DescribeConfig(sarama.ConfigResource{Type: sarama.TopicResource, Name: name})
+	if err != nil {
+		return nil, fmt.Errorf("查询 topic %s 的配置失败: %w", name, err)
+	}
+	for _, e := range entries {
+		known[e.Name] = true
+	}
+	return known, nil
+}
+
+// CheckConfigKeys 返回文件中不在 known 里的配置项，与某个已知配置项相近时附带建议
+func CheckConfigKeys(file *ExportFile, known map[string]bool) []string {
+	var problems []string
+	for _, t := range file.Topics {
+		keys := make([]string, 0, len(t.Configs))
+		for k := range t.Configs {
+			if !known[k] {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			p := fmt.Sprintf("topic %s: 未知配置项 %s", t.Name, k)
+			if s := closestKey(k, known); s != "" {
+				p += fmt.Sprintf("（是否为 %s？）", s)
+			}
+			problems = append(problems, p)
+		}
+	}
+	return problems
+}
+
+// closestKey 返回与 key 编辑距离不超过 2 的最接近的已知配置项，没有则返回空串
+func closestKey(key string, known map[string]bool) string {
+	best, bestDist := "", 3
+	for k := range known {
+		if d := editDistance(key, k); d < bestDist || (d == bestDist && best != "" && k < best) {
+			best, bestDist = k, d
+		}
+	}
+	return best
+}
+
+// editDistance 计算两个字符串的 Levenshtein 距离
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,115 @@
+package topicctl
+
+import (
+	"fmt"
+	"sort"
+)
+
+// FieldChange 描述一个字段从集群值到文件值的变化
+type FieldChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+// TopicChange 描述一个 topic 的所有字段变化
+type TopicChange struct {
+	Name    string        `json:"topic"`
+	Changes []FieldChange `json:"changes"`
+}
+
+// Diff 是期望状态与实际状态的对比结果；JSON 中 added 为将要新增（仅存在于文件）的 topic，
+// removed 为仅存在于集群的 topic
+type Diff struct {
+	OnlyInFile    []string      `json:"added"`
+	OnlyInCluster []string      `json:"removed"`
+	Changed       []TopicChange `json:"changed"`
+}
+
+// Empty 判断是否没有任何差异
+func (d *Diff) Empty() bool {
+	return len(d.OnlyInFile) == 0 && len(d.OnlyInCluster) == 0 && len(d.Changed) == 0
+}
+
+// DiffTopics 对比期望的 topic（want）和实际的 topic（have），结果按 want/have 的原有顺序排列
+func DiffTopics(want, have []Topic) *Diff {
+	d := &Diff{}
+
+	haveByName := make(map[string]Topic, len(have))
+	for _, t := range have {
+		haveByName[t.Name] = t
+	}
+	wantByName := make(map[string]Topic, len(want))
+	for _, t := range want {
+		wantByName[t.Name] = t
+	}
+
+	for _, w := range want {
+		h, ok := haveByName[w.Name]
+		if !ok {
+			d.OnlyInFile = append(d.OnlyInFile, w.Name)
+			continue
+		}
+		if changes := diffTopic(h, w); len(changes) > 0 {
+			d.Changed = append(d.Changed, TopicChange{Name: w.Name, Changes: changes})
+		}
+	}
+	for _, h := range have {
+		if _, ok := wantByName[h.Name]; !ok {
+			d.OnlyInCluster = append(d.OnlyInCluster, h.Name)
+		}
+	}
+
+	return d
+}
+
+// diffTopic 对比单个 topic 的分区数、副本数和配置项，两侧都记录了分区元数据时还对比 leader / 副本 / ISR；
+// 文件中未指定（为 0）的分区数和副本数不参与对比
+func diffTopic(have, want Topic) []FieldChange {
+	var changes []FieldChange
+
+	if want.Partitions != 0 && have.Partitions != want.Partitions {
+		changes = append(changes, FieldChange{
+			Field: "partitions",
+			Old:   fmt.Sprint(have.Partitions),
+			New:   fmt.Sprint(want.Partitions),
+		})
+	}
+	if want.ReplicationFactor != 0 && have.ReplicationFactor != want.ReplicationFactor {
+		changes = append(changes, FieldChange{
+			Field: "replication_factor",
+			Old:   fmt.Sprint(have.ReplicationFactor),
+			New:   fmt.Sprint(want.ReplicationFactor),
+		})
+	}
+
+	keys := make(map[string]struct{})
+	for k := range have.Configs {
+		keys[k] = struct{}{}
+	}
+	for k := range want.Configs {
+		keys[k] = struct{}{}
+	}
+	sorted := make([]string, 0, len(keys))
+	for k := range keys {
+		sorted = append(sorted, k)
+	}
+	sort.Strings(sorted)
+
+	for _, k := range sorted {
+		oldVal, inHave := have.Configs[k]
+		newVal, inWant := want.Configs[k]
+		if (inHave && inWant && oldVal == newVal) || newVal == Redacted {
+			continue
+		}
+		if !inHave {
+			oldVal = "<未设置>"
+		}
+		if !inWant {
+			newVal = "<未设置>"
+		}
+		changes = append(changes, FieldChange{Field: "configs." + k, Old: oldVal, New: newVal})
+	}
+
+	return append(changes, diffPartitionDetail(have.PartitionDetail, want.PartitionDetail)...)
+}
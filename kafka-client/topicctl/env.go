@@ -0,0 +1,58 @@
+package topicctl
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+)
+
+// envRef 匹配配置中的 ${VAR} 引用
+var envRef = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// ExpandEnv 用进程环境变量替换每个 topic 配置键和值中的 ${VAR}；
+// allowUnset 为 false 时引用了未设置的变量会返回错误，为 true 时替换为空串
+func ExpandEnv(file *ExportFile, allowUnset bool) error {
+	var errs []error
+	for i := range file.Topics {
+		t := &file.Topics[i]
+		if len(t.Configs) == 0 {
+			continue
+		}
+		expanded := make(map[string]string, len(t.Configs))
+		for k, v := range t.Configs {
+			key, err := expandEnv(k, allowUnset)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s 配置键 %s: %w", t.Name, k, err))
+				continue
+			}
+			value, err := expandEnv(v, allowUnset)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s 配置 %s: %w", t.Name, k, err))
+				continue
+			}
+			expanded[key] = value
+		}
+		t.Configs = expanded
+	}
+	return errors.Join(errs...)
+}
+
+// expandEnv 替换 s 中的 ${VAR}，收集全部未设置的变量名
+func expandEnv(s string, allowUnset bool) (string, error) {
+	var unset []string
+	out := envRef.ReplaceAllStringFunc(s, func(ref string) string {
+		name := envRef.FindStringSubmatch(ref)[1]
+		value, ok := os.LookupEnv(name)
+		if !ok && !allowUnset {
+			unset = append(unset, name)
+		}
+		return value
+	})
+	if len(unset) > 0 {
+		sort.Strings(unset)
+		return "", fmt.Errorf("环境变量未设置: %v", unset)
+	}
+	return out, nil
+}
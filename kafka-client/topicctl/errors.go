@@ -0,0 +1,89 @@
+package topicctl
+
+import (
+	"encoding/json"
+	"errors"
+	"net"
+
+	"github.com/IBM/sarama"
+)
+
+// ErrRetriesExhausted 由带重试的 ClusterAdmin 包装在最后一次失败的错误外，表示重试次数已用完
+var ErrRetriesExhausted = errors.New("重试次数已用完")
+
+// 失败 topic 的错误类别
+const (
+	CategoryAlreadyExists  = "already-exists"
+	CategoryInvalidConfig  = "invalid-config"
+	CategoryRetryExhausted = "retry-exhausted"
+	CategoryTimeout        = "timeout"
+	CategoryOther          = "other"
+)
+
+// TopicError 是单个 topic 的失败原因及其类别
+type TopicError struct {
+	Topic    string
+	Category string
+	Err      error
+}
+
+// Error 返回底层错误的描述
+func (e TopicError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap 支持 errors.Is / errors.As
+func (e TopicError) Unwrap() error {
+	return e.Err
+}
+
+// MarshalJSON 把错误序列化为描述文本
+func (e TopicError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Topic    string `json:"topic"`
+		Category string `json:"category"`
+		Error    string `json:"error"`
+	}{e.Topic, e.Category, e.Err.Error()})
+}
+
+// invalidErrors 是请求本身不合法（而不是集群出问题）时 broker 返回的错误
+var invalidErrors = []error{
+	sarama.ErrInvalidConfig,
+	sarama.ErrInvalidTopic,
+	sarama.ErrInvalidPartitions,
+	sarama.ErrInvalidReplicationFactor,
+	sarama.ErrInvalidReplicaAssignment,
+	sarama.ErrPolicyViolation,
+	sarama.ErrInvalidRequest,
+}
+
+// Classify 根据 sarama 错误码把错误归类；重试用完优先于其他类别
+func Classify(err error) string {
+	switch {
+	case errors.Is(err, ErrRetriesExhausted):
+		return CategoryRetryExhausted
+	case errors.Is(err, sarama.ErrTopicAlreadyExists):
+		return CategoryAlreadyExists
+	case errors.Is(err, ErrTopicTimeout), errors.Is(err, sarama.ErrRequestTimedOut):
+		return CategoryTimeout
+	}
+	for _, target := range invalidErrors {
+		if errors.Is(err, target) {
+			return CategoryInvalidConfig
+		}
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return CategoryTimeout
+	}
+	return CategoryOther
+}
+
+// CountByCategory 统计每个错误类别的 topic 数
+func (r Result) CountByCategory() map[string]int {
+	counts := make(map[string]int)
+	for _, f := range r.Failed {
+		counts[f.Category]++
+	}
+	return counts
+}
@@ -0,0 +1,11 @@
+package topicctl
+
+// Event 描述导入过程中对单个 topic 的一次操作
+type Event struct {
+	Action  string `json:"action"`
+	Topic   string `json:"topic,omitempty"`
+	Status  string `json:"status"`
+	Error   string `json:"error,omitempty"`
+	Detail  string `json:"detail,omitempty"`
+	Message string `json:"-"` // 人类可读的描述
+}
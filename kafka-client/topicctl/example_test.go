@@ -0,0 +1,59 @@
+package topicctl_test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/IBM/sarama"
+
+	"kafka-topicctl/topicctl"
+	"kafka-topicctl/topicctl/admintest"
+)
+
+func ExampleExportTopics() {
+	admin := admintest.New(map[string]sarama.TopicDetail{
+		"orders":             {NumPartitions: 3, ReplicationFactor: 1, ConfigEntries: map[string]*string{"retention.ms": str("86400000")}},
+		"payments":           {NumPartitions: 6, ReplicationFactor: 1},
+		"__consumer_offsets": {NumPartitions: 50, ReplicationFactor: 1},
+	})
+
+	filter, err := topicctl.NewFilter(true, "", "")
+	if err != nil {
+		panic(err)
+	}
+	file, err := topicctl.ExportTopics(context.Background(), admin, topicctl.ExportOptions{Filter: filter, NoTimestamp: true})
+	if err != nil {
+		panic(err)
+	}
+	for _, t := range file.Topics {
+		fmt.Println(t.Name, t.Partitions, t.Configs)
+	}
+	// Output:
+	// orders 3 map[retention.ms:86400000]
+	// payments 6 map[]
+}
+
+func ExampleImportTopics() {
+	admin := admintest.New(map[string]sarama.TopicDetail{
+		"orders": {NumPartitions: 3, ReplicationFactor: 1},
+	})
+	file := &topicctl.ExportFile{Topics: []topicctl.Topic{
+		{Name: "orders", Partitions: 6, ReplicationFactor: 1},
+		{Name: "payments", Partitions: 3, ReplicationFactor: 1, Configs: map[string]string{"cleanup.policy": "compact"}},
+	}}
+
+	res, err := topicctl.ImportTopics(context.Background(), admin, file, topicctl.ImportOptions{AlterPartitions: true})
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println("created:", res.Created)
+	fmt.Println("altered:", res.Altered)
+	for _, call := range admin.Calls() {
+		fmt.Println(call)
+	}
+	// Output:
+	// created: [payments]
+	// altered: [orders]
+	// CreatePartitions orders 6
+	// CreateTopic payments
+}
@@ -0,0 +1,180 @@
+package topicctl
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"sort"
+	"time"
+
+	"github.com/IBM/sarama"
+)
+
+// ExportOptions 控制 ExportTopics 的行为
+type ExportOptions struct {
+	Filter          *Filter
+	KafkaVersion    string // 写入导出文件的 Kafka 版本
+	IncludeDefaults bool   // 额外记录每个 topic 的全部生效配置（含默认值）
+	OnlyConfigs     bool   // 只导出名称和配置，省略分区数和副本数
+
+	IncludePartitionDetail bool // 额外记录每个分区的 leader、副本和 ISR
+
+	// ExportTime 非空时原样写入导出文件代替当前时间；NoTimestamp 时不写导出时间，便于生成可复现的文件
+	ExportTime  string
+	NoTimestamp bool
+
+	Retention *RetentionRange // 非 nil 时按 retention.ms 筛选 topic
+	Redact    []string        // 这些配置项的值在导出文件中替换为 Redacted
+
+	// ActiveWithin 非 0 时只导出最近这段时间内有写入的 topic，需要同时设置 Client
+	ActiveWithin time.Duration
+	Client       sarama.Client
+
+	// Sample 非 0 时在全部筛选之后随机保留 Sample 个 topic；Seed 相同则选中的 topic 相同
+	Sample int
+	Seed   int64
+
+	Log func(Event) // 告警回调，nil 表示不输出
+}
+
+// ListTopics 列出集群中通过过滤的 topic，按名称排序
+func ListTopics(admin Admin, filter *Filter) ([]Topic, error) {
+	topics, err := admin.ListTopics()
+	if err != nil {
+		return nil, err
+	}
+
+	var result []Topic
+	for name, detail := range topics {
+		if !filter.Match(name) {
+			continue
+		}
+		result = append(result, newTopic(name, detail, filter))
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result, nil
+}
+
+// ExportTopics 从集群导出 topic；ctx 取消后不再发起新的请求
+func ExportTopics(ctx context.Context, admin Admin, opts ExportOptions) (*ExportFile, error) {
+	var result []Topic
+	_, err := StreamTopics(ctx, admin, opts, func(t Topic) error {
+		result = append(result, t)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	exportTime := opts.ExportTime
+	switch {
+	case opts.NoTimestamp:
+		exportTime = ""
+	case exportTime == "":
+		exportTime = time.Now().Format(time.RFC3339)
+	}
+	return &ExportFile{
+		KafkaVersion: opts.KafkaVersion,
+		ExportTime:   exportTime,
+		Topics:       result,
+	}, nil
+}
+
+// StreamTopics 与 ExportTopics 相同，但每处理完一个 topic 就交给 emit，不汇总为 ExportFile；返回导出的 topic 数。
+// topic 列表（名称、分区数、副本数和配置覆盖项）仍由一次 ListTopics 全部读入内存，
+// 逐个释放的只是 IncludeDefaults / IncludePartitionDetail 额外查询到的数据，以及调用方在 emit 中的编码结果
+func StreamTopics(ctx context.Context, admin Admin, opts ExportOptions, emit func(Topic) error) (int, error) {
+	result, err := ListTopics(admin, opts.Filter)
+	if err != nil {
+		return 0, err
+	}
+	if opts.Retention.Active() {
+		result = filterRetention(result, opts.Retention, opts.Log)
+	}
+	if opts.ActiveWithin > 0 {
+		if result, err = ActiveTopics(opts.Client, result, time.Now().Add(-opts.ActiveWithin)); err != nil {
+			return 0, err
+		}
+	}
+	if opts.Sample > 0 {
+		result = sampleTopics(result, opts.Sample, opts.Seed)
+	}
+
+	for i := range result {
+		t := &result[i]
+		if opts.OnlyConfigs {
+			t.Partitions = 0
+			t.ReplicationFactor = 0
+		}
+		if opts.IncludePartitionDetail && i%partitionDetailBatch == 0 {
+			if err := ctx.Err(); err != nil {
+				return i, fmt.Errorf("导出已中断: %d/%d 个 topic 已查询分区元数据: %w", i, len(result), err)
+			}
+			if err := FillPartitionDetail(admin, result[i:min(i+partitionDetailBatch, len(result))]); err != nil {
+				return i, err
+			}
+		}
+		if opts.IncludeDefaults {
+			if err := ctx.Err(); err != nil {
+				return i, fmt.Errorf("导出已中断: %d/%d 个 topic 已查询配置: %w", i, len(result), err)
+			}
+			effective, err := effectiveConfigs(admin, t.Name, opts.Filter)
+			if err != nil {
+				return i, err
+			}
+			t.EffectiveConfigs = effective
+		}
+		redactConfigs(result[i:i+1], opts.Redact)
+
+		if err := emit(*t); err != nil {
+			return i, err
+		}
+		result[i] = Topic{}
+	}
+	return len(result), nil
+}
+
+// sampleTopics 用以 seed 初始化的随机数随机保留 n 个 topic，结果仍按名称排序；不足 n 个时全部保留
+func sampleTopics(topics []Topic, n int, seed int64) []Topic {
+	if len(topics) <= n {
+		return topics
+	}
+	rng := rand.New(rand.NewPCG(uint64(seed), 0))
+	rng.Shuffle(len(topics), func(i, j int) {
+		topics[i], topics[j] = topics[j], topics[i]
+	})
+	picked := topics[:n]
+	sort.Slice(picked, func(i, j int) bool {
+		return picked[i].Name < picked[j].Name
+	})
+	return picked
+}
+
+// effectiveConfigs 通过 DescribeConfig 查询 topic 的全部生效配置，并标记哪些是默认值；filter 剔除的配置项不会记录
+func effectiveConfigs(admin Admin, topic string, filter *Filter) (map[string]ConfigValue, error) {
+	entries, err := admin.DescribeConfig(sarama.ConfigResource{
+		Type: sarama.TopicResource,
+		Name: topic,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("查询 topic %s 的配置失败: %w", topic, err)
+	}
+
+	configs := make(map[string]ConfigValue, len(entries))
+	for _, e := range entries {
+		if !filter.KeepConfig(e.Name) {
+			continue
+		}
+		// 旧版协议只有 Default 标志，Source 为 Unknown；新版协议以 Source 区分 topic 级覆盖
+		isDefault := e.Default || (e.Source != sarama.SourceUnknown && e.Source != sarama.SourceTopic)
+		configs[e.Name] = ConfigValue{
+			Value:   e.Value,
+			Source:  e.Source.String(),
+			Default: isDefault,
+		}
+	}
+	return configs, nil
+}
@@ -0,0 +1,206 @@
+package topicctl
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ResolveFormat 确定文件格式；auto 时按扩展名判断（忽略 .gz/.zst 压缩扩展名），
+// .yaml/.yml 为 yaml，.ndjson/.jsonl 为 ndjson，其余为 json
+func ResolveFormat(path, format string) (string, error) {
+	switch format {
+	case "json", "yaml", FormatNDJSON:
+		return format, nil
+	case "", "auto":
+		switch strings.ToLower(filepath.Ext(trimCompressExt(path))) {
+		case ".yaml", ".yml":
+			return "yaml", nil
+		case ".ndjson", ".jsonl":
+			return FormatNDJSON, nil
+		}
+		return "json", nil
+	}
+	return "", fmt.Errorf("不支持的文件格式 %q（可选 json / yaml / ndjson / auto）", format)
+}
+
+// LoadFile 读取并解析导出文件；in 为 - 时从 stdin 读取，为目录时读取其中每个 topic 一个的文件，ndjson 时逐行解析
+func LoadFile(in, format string) (*ExportFile, error) {
+	if isDir(in) {
+		return loadDir(in, format)
+	}
+	if f, err := ResolveFormat(in, format); err == nil && f == FormatNDJSON {
+		return loadNDJSON(in)
+	}
+
+	var file ExportFile
+	if err := decodeFile(in, format, &file); err != nil {
+		return nil, err
+	}
+	return &file, nil
+}
+
+// decodeFile 按格式把文件解析到 v
+func decodeFile(in, format string, v any) error {
+	format, err := ResolveFormat(in, format)
+	if err != nil {
+		return err
+	}
+
+	data, err := readInput(in)
+	if err != nil {
+		return err
+	}
+
+	if format == "yaml" {
+		err = yaml.Unmarshal(data, v)
+	} else {
+		err = json.Unmarshal(data, v)
+	}
+	if err != nil {
+		return fmt.Errorf("解析 %s 失败: %w", in, err)
+	}
+	return nil
+}
+
+// isDir 判断路径是否为目录
+func isDir(path string) bool {
+	fi, err := os.Stat(path)
+	return err == nil && fi.IsDir()
+}
+
+// dirFiles 返回目录中的 topic 文件（.json / .yaml / .yml），按文件名排序
+func dirFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		switch strings.ToLower(filepath.Ext(e.Name())) {
+		case ".json", ".yaml", ".yml":
+			files = append(files, filepath.Join(dir, e.Name()))
+		}
+	}
+	return files, nil
+}
+
+// loadDir 读取目录中的每个文件，每个文件包含一个 Topic
+func loadDir(dir, format string) (*ExportFile, error) {
+	files, err := dirFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	file := &ExportFile{}
+	for _, path := range files {
+		var t Topic
+		if err := decodeFile(path, format, &t); err != nil {
+			return nil, err
+		}
+		file.Topics = append(file.Topics, t)
+	}
+	return file, nil
+}
+
+// WriteDir 把每个 topic 写成 dir 下单独的 <topic>.json（format 为 yaml 时为 .yaml），目录不存在时创建
+func WriteDir(dir, format string, file *ExportFile) error {
+	ext := ".json"
+	if format == "yaml" {
+		ext = ".yaml"
+	} else if format != "" && format != "auto" && format != "json" {
+		return fmt.Errorf("不支持的文件格式 %q（可选 json / yaml / auto）", format)
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	for _, t := range file.Topics {
+		data, err := encode(ext[1:], t)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(filepath.Join(dir, t.Name+ext), data, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// encode 按格式序列化 v；ndjson 只适用于 *ExportFile
+func encode(format string, v any) ([]byte, error) {
+	if format == "yaml" {
+		return yaml.Marshal(v)
+	}
+	if file, ok := v.(*ExportFile); ok && format == FormatNDJSON {
+		var buf bytes.Buffer
+		err := writeNDJSON(&buf, file.Topics)
+		return buf.Bytes(), err
+	}
+	return json.MarshalIndent(v, "", "  ")
+}
+
+// WriteFile 按指定格式写出导出文件；out 为 - 时写到 stdout，扩展名为 .gz/.zst 时压缩
+func WriteFile(out, format string, file *ExportFile) error {
+	return WriteFileCompressed(out, format, compressionFor(out), file)
+}
+
+// WriteFileCompressed 与 WriteFile 相同，但显式指定压缩方式（写到 stdout 时无法从扩展名判断）
+func WriteFileCompressed(out, format, compression string, file *ExportFile) error {
+	format, err := ResolveFormat(out, format)
+	if err != nil {
+		return err
+	}
+
+	data, err := encode(format, file)
+	if err != nil {
+		return err
+	}
+	if data, err = compress(compression, data); err != nil {
+		return err
+	}
+	if out == Stdio {
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+	return os.WriteFile(out, data, 0644)
+}
+
+// Stdio 作为输入/输出路径时表示 stdin / stdout
+const Stdio = "-"
+
+// stdin 缓存 stdin 的内容：stdin 只能读一次，而 --strict 时会先做 schema 校验再解析
+var stdin struct {
+	once sync.Once
+	data []byte
+	err  error
+}
+
+// readInput 读取输入文件；路径为 - 时读取 stdin。gzip / zstd 压缩的内容按 magic bytes 自动解压
+func readInput(in string) ([]byte, error) {
+	if in != Stdio {
+		data, err := os.ReadFile(in)
+		if err != nil {
+			return nil, err
+		}
+		return decompress(data)
+	}
+	stdin.once.Do(func() {
+		stdin.data, stdin.err = io.ReadAll(os.Stdin)
+		if stdin.err == nil {
+			stdin.data, stdin.err = decompress(stdin.data)
+		}
+	})
+	return stdin.data, stdin.err
+}
@@ -0,0 +1,88 @@
+package topicctl
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Filter 决定哪些 topic 和配置项参与处理；nil 表示不过滤
+type Filter struct {
+	ExcludeInternal bool
+	Include         *regexp.Regexp      // 非空时只保留匹配的 topic
+	Exclude         *regexp.Regexp      // 在 Include 之后剔除匹配的 topic
+	IncludePrefixes []string            // 非空时只保留以其中任一前缀开头的 topic，与 Include 同时生效
+	ExcludePrefixes []string            // 剔除以其中任一前缀开头的 topic
+	ExcludeConfigs  map[string]struct{} // 从每个 topic 的配置中剔除的 key
+}
+
+// NewFilter 编译 include/exclude 正则，空字符串表示不过滤
+func NewFilter(excludeInternal bool, include, exclude string) (*Filter, error) {
+	f := &Filter{ExcludeInternal: excludeInternal}
+
+	if include != "" {
+		re, err := regexp.Compile(include)
+		if err != nil {
+			return nil, fmt.Errorf("无效的 include 正则 %q: %w", include, err)
+		}
+		f.Include = re
+	}
+	if exclude != "" {
+		re, err := regexp.Compile(exclude)
+		if err != nil {
+			return nil, fmt.Errorf("无效的 exclude 正则 %q: %w", exclude, err)
+		}
+		f.Exclude = re
+	}
+
+	return f, nil
+}
+
+// Match 判断 topic 是否通过过滤
+func (f *Filter) Match(name string) bool {
+	if f == nil {
+		return true
+	}
+	if f.ExcludeInternal && IsInternal(name) {
+		return false
+	}
+	if f.Include != nil && !f.Include.MatchString(name) {
+		return false
+	}
+	if f.Exclude != nil && f.Exclude.MatchString(name) {
+		return false
+	}
+	if len(f.IncludePrefixes) > 0 && !hasAnyPrefix(name, f.IncludePrefixes) {
+		return false
+	}
+	return !hasAnyPrefix(name, f.ExcludePrefixes)
+}
+
+// hasAnyPrefix 判断 name 是否以 prefixes 中的任一前缀开头
+func hasAnyPrefix(name string, prefixes []string) bool {
+	for _, p := range prefixes {
+		if strings.HasPrefix(name, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// ExcludeConfig 把配置项 key 加入剔除列表
+func (f *Filter) ExcludeConfig(keys ...string) {
+	if f.ExcludeConfigs == nil {
+		f.ExcludeConfigs = make(map[string]struct{}, len(keys))
+	}
+	for _, k := range keys {
+		f.ExcludeConfigs[k] = struct{}{}
+	}
+}
+
+// KeepConfig 判断配置项是否保留
+func (f *Filter) KeepConfig(key string) bool {
+	if f == nil {
+		return true
+	}
+	_, excluded := f.ExcludeConfigs[key]
+	return !excluded
+}
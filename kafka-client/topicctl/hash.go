@@ -0,0 +1,25 @@
+package topicctl
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+)
+
+// Hash 计算 topic 列表的 SHA-256，用于判断集群拓扑自上次导出后是否变化。
+// topic 按名称排序，配置项依赖 encoding/json 按 key 排序，不包含 ExportTime 等元数据，
+// 因此集群不变时多次导出的结果相同
+func Hash(topics []Topic) (string, error) {
+	sorted := append([]Topic(nil), topics...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Name < sorted[j].Name
+	})
+
+	data, err := json.Marshal(sorted)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,672 @@
+package topicctl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/IBM/sarama"
+)
+
+// ImportOptions 控制 ImportTopics 的行为
+type ImportOptions struct {
+	IfNotExists     bool // 已存在则跳过
+	DryRun          bool // 只打印将要执行的操作
+	AlterPartitions bool // 已存在的 topic 分区数少于文件时扩容
+	AlterConfigs    bool // 已存在的 topic 配置与文件不一致时修改
+	Concurrency     int  // 并发创建 topic 的 worker 数
+	FailFast        bool // 出现第一个错误即停止；默认处理完全部 topic 后汇总返回错误
+	ServerValidate  bool // 以 validateOnly 方式提交创建请求，由 broker 校验但不实际创建
+
+	// TopicTimeout 非 0 时单个 CreateTopic 超过该时长即记为超时失败，继续处理其余 topic
+	TopicTimeout time.Duration
+
+	// BatchSize 大于 1 时每 BatchSize 个 topic 合并为一个 CreateTopics 请求直接发给 controller，
+	// 由 broker 在同一个 BatchTimeout 内处理；KafkaVersion 决定请求的协议版本
+	BatchSize    int
+	BatchTimeout time.Duration
+	KafkaVersion sarama.KafkaVersion
+
+	// DeleteMissingConfigs 修改配置时删除 topic 上存在而文件中没有的配置覆盖项；默认保留
+	DeleteMissingConfigs bool
+
+	Topics []string      // 非空时只处理文件中列出的这些 topic（按改名前的名称）
+	Rename NameTransform // 在 Topics 筛选之后改写 topic 名称
+
+	ReplicationFactor    int16 // 非 0 时用它覆盖文件中每个 topic 的副本数
+	MaxReplicationFactor bool  // 副本数超过集群 broker 数时降到 broker 数
+
+	// AllowUnspecified 允许创建 partitions / replication_factor 为 0（未指定）的 topic，以 -1 提交由 broker 使用默认值，
+	// 需要 Kafka >= 2.4（KIP-464）；默认这类 topic 记为失败。显式指定了 replica_assignment 的 topic 不受影响
+	AllowUnspecified bool
+
+	// PartitionsMultiplier 非 0 时把每个 topic 的分区数乘以该系数（向上取整，至少为 1）
+	PartitionsMultiplier float64
+
+	// Timing 时记录每个创建请求的耗时，写在创建成功的事件中，并在 Result.Latency 中汇总
+	Timing bool
+
+	// Preflight 非 nil 时在筛选、改名、调整分区数和副本数之后，对最终要处理的 topic 调用一次；
+	// 返回错误时 ImportTopics 不发起任何修改请求，直接返回该错误
+	Preflight func(file *ExportFile) error
+
+	Log      func(Event)           // 每个 topic 的处理结果回调，nil 表示不输出
+	Progress func(done, total int) // 每处理完一个 topic 的进度回调，nil 表示不报告
+}
+
+// log 把事件交给 Log 回调
+func (o *ImportOptions) log(e Event) {
+	if o.Log != nil {
+		o.Log(e)
+	}
+}
+
+// progress 把进度交给 Progress 回调
+func (o *ImportOptions) progress(done, total int) {
+	if o.Progress != nil {
+		o.Progress(done, total)
+	}
+}
+
+// ErrTopicTimeout 表示单个 topic 的创建请求超过了 ImportOptions.TopicTimeout
+var ErrTopicTimeout = errors.New("创建请求超时")
+
+// Result 汇总一次导入中创建、修改、跳过和失败的 topic；dry-run 时为将要执行的操作。
+// 可直接序列化为 JSON 供机器读取
+type Result struct {
+	Created  []string     `json:"created"`
+	Altered  []string     `json:"altered"`
+	Skipped  []string     `json:"skipped"`
+	TimedOut []string     `json:"timed_out"` // 创建请求超时的 topic，同时计入 Failed
+	Failed   []TopicError `json:"failed"`    // 处理失败的 topic（含超时）及错误类别
+
+	Latency *LatencySummary `json:"latency,omitempty"` // 仅 ImportOptions.Timing 时记录
+}
+
+// Changed 判断是否创建或修改了 topic
+func (r Result) Changed() bool {
+	return len(r.Created) > 0 || len(r.Altered) > 0
+}
+
+// ImportTopics 在集群上创建（或按选项修改）文件中的 topic；ctx 取消后不再发起新的请求
+func ImportTopics(ctx context.Context, admin Admin, file *ExportFile, opts ImportOptions) (Result, error) {
+	var res Result
+
+	if len(opts.Topics) > 0 {
+		file = selectTopics(file, opts.Topics, &opts)
+	}
+	if !opts.Rename.Empty() {
+		var err error
+		if file, err = renameTopics(file, &opts); err != nil {
+			return res, err
+		}
+	}
+	if opts.PartitionsMultiplier != 0 {
+		file = scalePartitions(file, &opts)
+	}
+	if opts.ReplicationFactor != 0 || opts.MaxReplicationFactor {
+		var err error
+		if file, err = overrideReplication(admin, file, &opts); err != nil {
+			return res, err
+		}
+	}
+	if opts.Preflight != nil {
+		if err := opts.Preflight(file); err != nil {
+			return res, err
+		}
+	}
+
+	// 先列出一次现有 topic：if-not-exists 时在本地跳过已存在的 topic，不再为每个 topic 发起注定失败的创建请求；
+	// dry-run 时据此模拟创建结果，修改已存在 topic 时需要其当前状态。
+	// 列出之后才被创建的 topic（并发竞争）仍由创建请求返回的 ErrTopicAlreadyExists 处理
+	alter := opts.AlterPartitions || opts.AlterConfigs
+	var existing map[string]sarama.TopicDetail
+	if opts.DryRun || alter || opts.IfNotExists {
+		var err error
+		existing, err = admin.ListTopics()
+		if err != nil {
+			return res, err
+		}
+	}
+
+	// 非 fail-fast 时收集每个 topic 的错误，最后一并返回
+	var errs []error
+	fail := func(name string, err error) bool {
+		errs = append(errs, err)
+		res.Failed = append(res.Failed, TopicError{Topic: name, Category: Classify(err), Err: err})
+		return opts.FailFast
+	}
+
+	var toCreate []Topic
+	for _, t := range file.Topics {
+		if ctx.Err() != nil {
+			return res, canceled(ctx, res, len(file.Topics))
+		}
+		if keys := redactedKeys(t); len(keys) > 0 {
+			detail := strings.Join(keys, ",")
+			opts.log(Event{
+				Action: "redacted", Topic: t.Name, Status: "warning", Detail: detail,
+				Message: fmt.Sprintf("⚠️  topic %s 的配置 %s 已脱敏，导入时跳过，请另行设置", t.Name, detail),
+			})
+		}
+		if cur, ok := existing[t.Name]; ok {
+			if alter {
+				changed, err := updateTopic(admin, t, cur, &opts)
+				if err != nil {
+					if fail(t.Name, err) {
+						return res, err
+					}
+					continue
+				}
+				if changed {
+					res.Altered = append(res.Altered, t.Name)
+				} else {
+					res.Skipped = append(res.Skipped, t.Name)
+				}
+				continue
+			}
+			if opts.IfNotExists {
+				ev := Event{
+					Action: "create", Topic: t.Name, Status: "skipped",
+					Message: fmt.Sprintf("⚠️  跳过已存在 topic: %s", t.Name),
+				}
+				if opts.DryRun {
+					ev.Detail = "dry-run"
+					ev.Message = fmt.Sprintf("⚠️  [dry-run] 跳过已存在 topic: %s", t.Name)
+				}
+				opts.log(ev)
+				res.Skipped = append(res.Skipped, t.Name)
+				continue
+			}
+			if opts.DryRun {
+				if err := fmt.Errorf("topic %s: %w", t.Name, sarama.ErrTopicAlreadyExists); fail(t.Name, err) {
+					return res, err
+				}
+				continue
+			}
+		}
+
+		if !opts.AllowUnspecified && len(t.ReplicaAssignment) == 0 && (t.Partitions == 0 || t.ReplicationFactor == 0) {
+			err := fmt.Errorf("topic %s 未指定 partitions 或 replication_factor（文件中为 0），如需使用 broker 默认值请启用 AllowUnspecified（--allow-unspecified）", t.Name)
+			if fail(t.Name, err) {
+				return res, err
+			}
+			continue
+		}
+
+		// 提交前检查显式分配，避免 broker 返回难以理解的协议错误
+		if problems := validateAssignment(t); len(problems) > 0 {
+			err := fmt.Errorf("topic %s 的 replica_assignment 不合法: %s: %w", t.Name, strings.Join(problems, "; "), sarama.ErrInvalidReplicaAssignment)
+			if fail(t.Name, err) {
+				return res, err
+			}
+			continue
+		}
+
+		if opts.DryRun {
+			detail := fmt.Sprintf("partitions=%d, replication_factor=%d, configs=%d",
+				t.Partitions, t.ReplicationFactor, len(t.Configs))
+			opts.log(Event{
+				Action: "create", Topic: t.Name, Status: "dry-run", Detail: detail,
+				Message: fmt.Sprintf("📝 [dry-run] 将创建 topic: %s (%s)", t.Name, detail),
+			})
+			res.Created = append(res.Created, t.Name)
+			continue
+		}
+
+		toCreate = append(toCreate, t)
+	}
+
+	// 已存在和 dry-run 的 topic 在上面的循环中已处理完，剩下的进度随创建逐个推进
+	total := len(file.Topics)
+	done := total - len(toCreate)
+	opts.progress(done, total)
+
+	results := createTopics(ctx, admin, toCreate, &opts, func() {
+		done++
+		opts.progress(done, total)
+	})
+
+	for _, r := range results {
+		if errors.Is(r.Err, ErrTopicTimeout) {
+			opts.log(Event{
+				Action: "create", Topic: r.Name, Status: "error", Detail: "timeout", Error: r.Err.Error(),
+				Message: fmt.Sprintf("⏱️  创建 topic 超时: %s (%s)", r.Name, opts.TopicTimeout),
+			})
+			res.TimedOut = append(res.TimedOut, r.Name)
+			fail(r.Name, fmt.Errorf("创建 topic %s 失败: %w", r.Name, r.Err))
+			continue
+		}
+		if opts.ServerValidate && !errors.Is(r.Err, sarama.ErrTopicAlreadyExists) {
+			if r.Err != nil {
+				opts.log(Event{
+					Action: "create", Topic: r.Name, Status: "invalid", Detail: "server-validate", Error: r.Err.Error(),
+					Message: fmt.Sprintf("❌ [server-validate] 校验失败: %s: %v", r.Name, r.Err),
+				})
+				fail(r.Name, fmt.Errorf("topic %s 未通过 broker 校验: %w", r.Name, r.Err))
+				continue
+			}
+			opts.log(Event{
+				Action: "create", Topic: r.Name, Status: "valid", Detail: "server-validate",
+				Message: fmt.Sprintf("✅ [server-validate] 校验通过: %s", r.Name),
+			})
+			res.Created = append(res.Created, r.Name)
+			continue
+		}
+		if r.Err != nil {
+			// 只有已存在（列出之后才被并发创建）才算跳过，其余错误（如配置不合法）即使 if-not-exists 也记为失败
+			if opts.IfNotExists && errors.Is(r.Err, sarama.ErrTopicAlreadyExists) {
+				opts.log(Event{
+					Action: "create", Topic: r.Name, Status: "skipped", Error: r.Err.Error(),
+					Message: fmt.Sprintf("⚠️  跳过已存在 topic: %s", r.Name),
+				})
+				res.Skipped = append(res.Skipped, r.Name)
+				continue
+			}
+			fail(r.Name, fmt.Errorf("创建 topic %s 失败: %w", r.Name, r.Err))
+			continue
+		}
+		ev := Event{
+			Action: "create", Topic: r.Name, Status: "created",
+			Message: fmt.Sprintf("✅ 创建 topic: %s", r.Name),
+		}
+		if opts.Timing {
+			ev.Detail = "duration=" + r.Duration.String()
+			ev.Message += fmt.Sprintf("（耗时 %s）", r.Duration)
+		}
+		opts.log(ev)
+		res.Created = append(res.Created, r.Name)
+	}
+
+	if opts.Timing {
+		durations := make([]time.Duration, len(results))
+		for i, r := range results {
+			durations[i] = r.Duration
+		}
+		res.Latency = summarizeLatency(durations)
+	}
+
+	if ctx.Err() != nil && len(results) < len(toCreate) {
+		return res, canceled(ctx, res, len(file.Topics))
+	}
+
+	switch len(errs) {
+	case 0:
+		return res, nil
+	case 1:
+		return res, errs[0]
+	}
+	return res, fmt.Errorf("%d 个 topic 处理失败:\n%w", len(errs), errors.Join(errs...))
+}
+
+// selectTopics 只保留 names 中列出的 topic；文件中不存在的名称输出警告后忽略
+func selectTopics(file *ExportFile, names []string, opts *ImportOptions) *ExportFile {
+	wanted := make(map[string]bool, len(names))
+	for _, name := range names {
+		wanted[name] = true
+	}
+
+	selected := *file
+	selected.Topics = nil
+	found := make(map[string]bool, len(names))
+	for _, t := range file.Topics {
+		if wanted[t.Name] {
+			selected.Topics = append(selected.Topics, t)
+			found[t.Name] = true
+		}
+	}
+
+	for _, name := range names {
+		if !found[name] {
+			opts.log(Event{
+				Action: "select", Topic: name, Status: "warning", Detail: "文件中不存在",
+				Message: fmt.Sprintf("⚠️  文件中没有 topic %s，已忽略", name),
+			})
+			found[name] = true
+		}
+	}
+	return &selected
+}
+
+// overrideReplication 按选项覆盖或封顶每个 topic 的副本数，返回修改后的副本，不改动传入的 file；
+// 显式指定了 replica_assignment 的 topic 以分配为准，不做覆盖
+func overrideReplication(admin Admin, file *ExportFile, opts *ImportOptions) (*ExportFile, error) {
+	var max int16
+	if opts.MaxReplicationFactor {
+		brokers, _, err := admin.DescribeCluster()
+		if err != nil {
+			return nil, fmt.Errorf("查询 broker 数失败: %w", err)
+		}
+		max = int16(len(brokers))
+	}
+
+	out := *file
+	out.Topics = make([]Topic, len(file.Topics))
+	for i, t := range file.Topics {
+		if len(t.ReplicaAssignment) == 0 {
+			rf := t.ReplicationFactor
+			if opts.ReplicationFactor != 0 {
+				rf = opts.ReplicationFactor
+			}
+			if max > 0 && rf > max {
+				rf = max
+			}
+			if rf != t.ReplicationFactor {
+				detail := fmt.Sprintf("%d -> %d", t.ReplicationFactor, rf)
+				opts.log(Event{
+					Action: "override-replication-factor", Topic: t.Name, Status: "warning", Detail: detail,
+					Message: fmt.Sprintf("⚠️  覆盖 topic 副本数: %s %s", t.Name, detail),
+				})
+				t.ReplicationFactor = rf
+			}
+		}
+		out.Topics[i] = t
+	}
+	return &out, nil
+}
+
+// scalePartitions 把每个 topic 的分区数乘以 PartitionsMultiplier 并向上取整，至少为 1；
+// 未指定分区数或显式指定了 replica_assignment 的 topic 不调整
+func scalePartitions(file *ExportFile, opts *ImportOptions) *ExportFile {
+	out := *file
+	out.Topics = make([]Topic, len(file.Topics))
+	for i, t := range file.Topics {
+		if t.Partitions > 0 && len(t.ReplicaAssignment) == 0 {
+			n := int32(math.Ceil(float64(t.Partitions) * opts.PartitionsMultiplier))
+			if n < 1 {
+				n = 1
+			}
+			if n != t.Partitions {
+				detail := fmt.Sprintf("%d -> %d", t.Partitions, n)
+				opts.log(Event{
+					Action: "scale-partitions", Topic: t.Name, Status: "warning", Detail: detail,
+					Message: fmt.Sprintf("⚠️  调整 topic 分区数: %s %s", t.Name, detail),
+				})
+				t.Partitions = n
+			}
+		}
+		out.Topics[i] = t
+	}
+	return &out
+}
+
+// canceled 生成 ctx 取消时的错误，说明取消前完成了多少个 topic
+func canceled(ctx context.Context, res Result, total int) error {
+	done := len(res.Created) + len(res.Altered) + len(res.Skipped)
+	return fmt.Errorf("导入已中断: %d 个 topic 已完成（创建 %d 个, 跳过 %d 个），%d 个未处理: %w",
+		done, len(res.Created), len(res.Skipped), total-done, ctx.Err())
+}
+
+// createResult 是单个 topic 的创建结果
+type createResult struct {
+	Name     string
+	Err      error
+	Duration time.Duration // 创建请求的耗时，批量创建时为整批的耗时
+}
+
+// createTopics 用最多 concurrency 个 worker 并发创建 topic（设置了 BatchSize 时每个 worker 一次提交一批），结果按名称排序；
+// fail-fast 且不跳过错误时，出现失败后剩余 topic 不再创建；ctx 取消后同样停止，未创建的 topic 不出现在结果中。
+// onDone 在每个 topic 的创建结果返回后调用，调用时持有锁，无需自行同步
+func createTopics(ctx context.Context, admin Admin, topics []Topic, opts *ImportOptions, onDone func()) []createResult {
+	concurrency := opts.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	failFast := opts.FailFast && !opts.IfNotExists
+
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		failed  bool
+		results []createResult
+	)
+
+	size := opts.BatchSize
+	if size < 1 {
+		size = 1
+	}
+
+	jobs := make(chan []Topic)
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for batch := range jobs {
+				mu.Lock()
+				stop := (failFast && failed) || ctx.Err() != nil
+				mu.Unlock()
+				if stop {
+					continue
+				}
+
+				var batchResults []createResult
+				begin := time.Now()
+				if opts.BatchSize > 1 {
+					batchResults = createBatch(admin, batch, opts)
+				} else {
+					batchResults = []createResult{{Name: batch[0].Name, Err: createTopic(admin, batch[0], opts)}}
+				}
+				elapsed := time.Since(begin)
+				for i := range batchResults {
+					batchResults[i].Duration = elapsed
+				}
+
+				mu.Lock()
+				for _, r := range batchResults {
+					results = append(results, r)
+					if r.Err != nil {
+						failed = true
+					}
+					onDone()
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+feed:
+	for start := 0; start < len(topics); start += size {
+		end := min(start+size, len(topics))
+		select {
+		case jobs <- topics[start:end]:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Name < results[j].Name
+	})
+	return results
+}
+
+// createTopic 创建单个 topic；sarama 的 CreateTopic 不接受 context，
+// 设置了 TopicTimeout 时在 goroutine 中调用并等待，超时后不再等待该请求返回
+func createTopic(admin Admin, t Topic, opts *ImportOptions) error {
+	if opts.TopicTimeout <= 0 {
+		return admin.CreateTopic(t.Name, topicDetail(t), opts.ServerValidate)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- admin.CreateTopic(t.Name, topicDetail(t), opts.ServerValidate)
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(opts.TopicTimeout):
+		return fmt.Errorf("%w（%s）", ErrTopicTimeout, opts.TopicTimeout)
+	}
+}
+
+// createBatch 把一批 topic 合并为一个 CreateTopics 请求发给 controller，返回每个 topic 的结果；
+// 请求整体失败时批内每个 topic 都记为该错误。该请求绕过 ClusterAdmin，不经过外层的重试
+func createBatch(admin Admin, batch []Topic, opts *ImportOptions) []createResult {
+	results := make([]createResult, len(batch))
+	details := make(map[string]*sarama.TopicDetail, len(batch))
+	for i, t := range batch {
+		results[i].Name = t.Name
+		details[t.Name] = topicDetail(t)
+	}
+
+	setAll := func(err error) []createResult {
+		for i := range results {
+			results[i].Err = err
+		}
+		return results
+	}
+
+	controller, err := admin.Controller()
+	if err != nil {
+		return setAll(fmt.Errorf("连接 controller 失败: %w", err))
+	}
+	req := sarama.NewCreateTopicsRequest(opts.KafkaVersion, details, opts.BatchTimeout, opts.ServerValidate)
+	rsp, err := controller.CreateTopics(req)
+	if err != nil {
+		return setAll(err)
+	}
+
+	succeeded := 0
+	for i := range results {
+		topicErr, ok := rsp.TopicErrors[results[i].Name]
+		switch {
+		case !ok:
+			results[i].Err = sarama.ErrIncompleteResponse
+		case !errors.Is(topicErr.Err, sarama.ErrNoError):
+			results[i].Err = topicErr
+		default:
+			succeeded++
+		}
+	}
+
+	detail := fmt.Sprintf("succeeded=%d, failed=%d", succeeded, len(batch)-succeeded)
+	opts.log(Event{
+		Action: "create-batch", Status: "done", Detail: detail,
+		Message: fmt.Sprintf("📦 批量创建 %d 个 topic（%s ... %s）: 成功 %d 个，失败 %d 个",
+			len(batch), batch[0].Name, batch[len(batch)-1].Name, succeeded, len(batch)-succeeded),
+	})
+	return results
+}
+
+// updateTopic 让已存在的 topic 向文件定义靠拢，返回是否做了处理；分区只增不减
+func updateTopic(admin Admin, t Topic, cur sarama.TopicDetail, opts *ImportOptions) (bool, error) {
+	prefix, status := "", "altered"
+	if opts.DryRun {
+		prefix, status = "[dry-run] ", "dry-run"
+	}
+
+	handled := false
+	if opts.AlterPartitions {
+		switch {
+		case t.Partitions == 0:
+			// 文件未指定分区数，不做处理
+		case t.Partitions > cur.NumPartitions:
+			if problems := validateNewPartitions(t, cur); len(problems) > 0 {
+				return false, fmt.Errorf("topic %s 的 new_partition_assignment 不合法: %s: %w",
+					t.Name, strings.Join(problems, "; "), sarama.ErrInvalidReplicaAssignment)
+			}
+			if !opts.DryRun {
+				if err := admin.CreatePartitions(t.Name, t.Partitions, t.NewPartitionAssignment, false); err != nil {
+					return false, fmt.Errorf("扩容 topic %s 分区失败: %w", t.Name, err)
+				}
+			}
+			detail := fmt.Sprintf("%d -> %d", cur.NumPartitions, t.Partitions)
+			opts.log(Event{
+				Action: "alter-partitions", Topic: t.Name, Status: status, Detail: detail,
+				Message: fmt.Sprintf("📈 %s扩容 topic 分区: %s %s", prefix, t.Name, detail),
+			})
+			handled = true
+		case t.Partitions < cur.NumPartitions:
+			detail := fmt.Sprintf("文件分区数 %d 小于集群当前 %d，Kafka 不支持缩减分区，已忽略", t.Partitions, cur.NumPartitions)
+			opts.log(Event{
+				Action: "alter-partitions", Topic: t.Name, Status: "warning", Detail: detail,
+				Message: fmt.Sprintf("⚠️  %stopic %s %s", prefix, t.Name, detail),
+			})
+		}
+	}
+
+	if opts.AlterConfigs {
+		changed, err := alterTopicConfigs(admin, t, cur, opts)
+		if err != nil {
+			return false, err
+		}
+		handled = handled || changed
+	}
+
+	if !handled {
+		opts.log(Event{
+			Action: "create", Topic: t.Name, Status: "skipped",
+			Message: fmt.Sprintf("⚠️  %s跳过已存在 topic: %s", prefix, t.Name),
+		})
+	}
+	return handled, nil
+}
+
+// alterTopicConfigs 把文件中与集群不一致的配置项写入 topic，返回是否有修改。
+// 使用 IncrementalAlterConfig 只 SET 变化的 key，不会覆盖文件中没有的配置；
+// DeleteMissingConfigs 时把集群上存在而文件中没有的覆盖项 DELETE 掉（恢复为默认值）
+func alterTopicConfigs(admin Admin, t Topic, cur sarama.TopicDetail, opts *ImportOptions) (bool, error) {
+	prefix, status := "", "altered"
+	if opts.DryRun {
+		prefix, status = "[dry-run] ", "dry-run"
+	}
+
+	current := newTopic(t.Name, cur, nil).Configs
+
+	entries := make(map[string]sarama.IncrementalAlterConfigsEntry)
+	for k, v := range t.Configs {
+		if v == Redacted {
+			continue
+		}
+		if old, ok := current[k]; !ok || old != v {
+			v := v
+			entries[k] = sarama.IncrementalAlterConfigsEntry{Operation: sarama.IncrementalAlterConfigsOperationSet, Value: &v}
+		}
+	}
+	if opts.DeleteMissingConfigs {
+		for k := range current {
+			if _, ok := t.Configs[k]; !ok {
+				entries[k] = sarama.IncrementalAlterConfigsEntry{Operation: sarama.IncrementalAlterConfigsOperationDelete}
+			}
+		}
+	}
+	if len(entries) == 0 {
+		return false, nil
+	}
+
+	keys := make([]string, 0, len(entries))
+	for k := range entries {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	if !opts.DryRun {
+		if err := admin.IncrementalAlterConfig(sarama.TopicResource, t.Name, entries, false); err != nil {
+			return false, fmt.Errorf("修改 topic %s 配置失败: %w", t.Name, err)
+		}
+	}
+
+	for _, k := range keys {
+		old, ok := current[k]
+		if !ok {
+			old = "<未设置>"
+		}
+		newVal, ok := t.Configs[k]
+		if !ok {
+			newVal = "<删除>"
+		}
+		detail := fmt.Sprintf("%s: %s -> %s", k, old, newVal)
+		opts.log(Event{
+			Action: "alter-config", Topic: t.Name, Status: status, Detail: detail,
+			Message: fmt.Sprintf("🔧 %s修改 topic 配置: %s %s", prefix, t.Name, detail),
+		})
+	}
+	return true, nil
+}
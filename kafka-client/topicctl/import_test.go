@@ -0,0 +1,250 @@
+package topicctl_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/IBM/sarama"
+
+	"kafka-topicctl/topicctl"
+	"kafka-topicctl/topicctl/admintest"
+)
+
+// str 返回 s 的指针，用于构造 sarama.TopicDetail 的配置项
+func str(s string) *string { return &s }
+
+func TestImportTopics(t *testing.T) {
+	orders := func(partitions int32, retention string) sarama.TopicDetail {
+		return sarama.TopicDetail{
+			NumPartitions:     partitions,
+			ReplicationFactor: 1,
+			ConfigEntries:     map[string]*string{"retention.ms": str(retention)},
+		}
+	}
+	want := func(partitions int32, retention string) []topicctl.Topic {
+		return []topicctl.Topic{{
+			Name:              "orders",
+			Partitions:        partitions,
+			ReplicationFactor: 1,
+			Configs:           map[string]string{"retention.ms": retention},
+		}}
+	}
+
+	tests := []struct {
+		name     string
+		existing map[string]sarama.TopicDetail
+		topics   []topicctl.Topic
+		opts     topicctl.ImportOptions
+
+		created, altered, skipped []string
+		calls                     []string
+		partitions                int32  // 导入后 orders 的分区数
+		retention                 string // 导入后 orders 的 retention.ms
+	}{
+		{
+			name:       "create",
+			topics:     want(3, "1000"),
+			created:    []string{"orders"},
+			calls:      []string{"CreateTopic orders"},
+			partitions: 3,
+			retention:  "1000",
+		},
+		{
+			name:       "if-not-exists skips existing",
+			existing:   map[string]sarama.TopicDetail{"orders": orders(3, "1000")},
+			topics:     want(6, "2000"),
+			opts:       topicctl.ImportOptions{IfNotExists: true},
+			skipped:    []string{"orders"},
+			partitions: 3,
+			retention:  "1000",
+		},
+		{
+			name:       "alter-partitions",
+			existing:   map[string]sarama.TopicDetail{"orders": orders(3, "1000")},
+			topics:     want(6, "1000"),
+			opts:       topicctl.ImportOptions{AlterPartitions: true},
+			altered:    []string{"orders"},
+			calls:      []string{"CreatePartitions orders 6"},
+			partitions: 6,
+			retention:  "1000",
+		},
+		{
+			name:       "alter-configs",
+			existing:   map[string]sarama.TopicDetail{"orders": orders(3, "1000")},
+			topics:     want(3, "2000"),
+			opts:       topicctl.ImportOptions{AlterConfigs: true},
+			altered:    []string{"orders"},
+			calls:      []string{"IncrementalAlterConfig orders [retention.ms]"},
+			partitions: 3,
+			retention:  "2000",
+		},
+		{
+			name:       "alter unchanged topic is skipped",
+			existing:   map[string]sarama.TopicDetail{"orders": orders(3, "1000")},
+			topics:     want(3, "1000"),
+			opts:       topicctl.ImportOptions{AlterPartitions: true, AlterConfigs: true},
+			skipped:    []string{"orders"},
+			partitions: 3,
+			retention:  "1000",
+		},
+		{
+			name:       "dry-run create",
+			topics:     want(3, "1000"),
+			opts:       topicctl.ImportOptions{DryRun: true},
+			created:    []string{"orders"},
+			partitions: 0, // 未创建
+		},
+		{
+			name:       "dry-run alter",
+			existing:   map[string]sarama.TopicDetail{"orders": orders(3, "1000")},
+			topics:     want(6, "2000"),
+			opts:       topicctl.ImportOptions{DryRun: true, AlterPartitions: true, AlterConfigs: true},
+			altered:    []string{"orders"},
+			partitions: 3,
+			retention:  "1000",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			admin := admintest.New(tt.existing)
+			res, err := topicctl.ImportTopics(context.Background(), admin, &topicctl.ExportFile{Topics: tt.topics}, tt.opts)
+			if err != nil {
+				t.Fatalf("ImportTopics: %v", err)
+			}
+			if !slices.Equal(res.Created, tt.created) || !slices.Equal(res.Altered, tt.altered) || !slices.Equal(res.Skipped, tt.skipped) {
+				t.Errorf("result = created %v, altered %v, skipped %v; want %v, %v, %v",
+					res.Created, res.Altered, res.Skipped, tt.created, tt.altered, tt.skipped)
+			}
+			if calls := admin.Calls(); !slices.Equal(calls, tt.calls) {
+				t.Errorf("calls = %q, want %q", calls, tt.calls)
+			}
+
+			got, ok := admin.Topic("orders")
+			if tt.partitions == 0 {
+				if ok {
+					t.Errorf("topic orders exists after dry-run")
+				}
+				return
+			}
+			if got.NumPartitions != tt.partitions {
+				t.Errorf("partitions = %d, want %d", got.NumPartitions, tt.partitions)
+			}
+			if v := got.ConfigEntries["retention.ms"]; v == nil || *v != tt.retention {
+				t.Errorf("retention.ms = %v, want %s", v, tt.retention)
+			}
+		})
+	}
+}
+
+func TestImportTopicsReportsCreateErrors(t *testing.T) {
+	admin := admintest.New(nil)
+	file := &topicctl.ExportFile{Topics: []topicctl.Topic{
+		{Name: "ok", Partitions: 1, ReplicationFactor: 1},
+		{Name: "too-many-replicas", Partitions: 1, ReplicationFactor: 3},
+	}}
+
+	res, err := topicctl.ImportTopics(context.Background(), admin, file, topicctl.ImportOptions{})
+	if err == nil {
+		t.Fatal("ImportTopics succeeded, want error for replication factor above broker count")
+	}
+	if !slices.Equal(res.Created, []string{"ok"}) {
+		t.Errorf("created = %v, want [ok]", res.Created)
+	}
+	if len(res.Failed) != 1 || res.Failed[0].Topic != "too-many-replicas" {
+		t.Errorf("failed = %v, want too-many-replicas", res.Failed)
+	}
+}
+
+// 文件分区数小于集群时只告警，不算修改：import 不会因此以 exitChanged 退出
+func TestImportTopicsIgnoresPartitionShrink(t *testing.T) {
+	admin := admintest.New(map[string]sarama.TopicDetail{
+		"orders": {NumPartitions: 6, ReplicationFactor: 1},
+	})
+	file := &topicctl.ExportFile{Topics: []topicctl.Topic{{Name: "orders", Partitions: 3, ReplicationFactor: 1}}}
+
+	var warnings []string
+	res, err := topicctl.ImportTopics(context.Background(), admin, file, topicctl.ImportOptions{
+		AlterPartitions: true,
+		Log: func(e topicctl.Event) {
+			if e.Status == "warning" {
+				warnings = append(warnings, e.Action)
+			}
+		},
+	})
+	if err != nil {
+		t.Fatalf("ImportTopics: %v", err)
+	}
+	if len(res.Altered) != 0 || res.Changed() {
+		t.Errorf("altered = %v, changed = %v; want none", res.Altered, res.Changed())
+	}
+	if !slices.Equal(res.Skipped, []string{"orders"}) {
+		t.Errorf("skipped = %v, want [orders]", res.Skipped)
+	}
+	if !slices.Equal(warnings, []string{"alter-partitions"}) {
+		t.Errorf("warnings = %v, want [alter-partitions]", warnings)
+	}
+	if calls := admin.Calls(); len(calls) != 0 {
+		t.Errorf("calls = %q, want none", calls)
+	}
+}
+
+// Preflight 看到的是覆盖副本数之后的 topic，返回错误时不发起任何修改
+func TestImportTopicsPreflightSeesReplicationOverride(t *testing.T) {
+	file := &topicctl.ExportFile{Topics: []topicctl.Topic{{
+		Name: "orders", Partitions: 3, ReplicationFactor: 3,
+		Configs: map[string]string{"min.insync.replicas": "2"},
+	}}}
+
+	tests := []struct {
+		name    string
+		rf      int16
+		wantErr bool
+	}{
+		{name: "override below min.insync.replicas", rf: 1, wantErr: true},
+		{name: "override keeps min.insync.replicas satisfiable", rf: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			admin := admintest.New(nil)
+			admin.SetBrokers(3)
+			_, err := topicctl.ImportTopics(context.Background(), admin, file, topicctl.ImportOptions{
+				ReplicationFactor: tt.rf,
+				Preflight: func(f *topicctl.ExportFile) error {
+					if problems := topicctl.CheckMinISR(f); len(problems) > 0 {
+						return errors.New(problems[0])
+					}
+					return nil
+				},
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if calls := admin.Calls(); tt.wantErr && len(calls) != 0 {
+				t.Errorf("calls = %q, want none after preflight failure", calls)
+			}
+			if d, ok := admin.Topic("orders"); !tt.wantErr && (!ok || d.ReplicationFactor != tt.rf) {
+				t.Errorf("orders = %+v, %v; want replication factor %d", d, ok, tt.rf)
+			}
+		})
+	}
+}
+
+// 未指定分区数 / 副本数的 topic 默认记为失败，AllowUnspecified 时以 broker 默认值创建
+func TestImportTopicsUnspecified(t *testing.T) {
+	file := &topicctl.ExportFile{Topics: []topicctl.Topic{{Name: "orders"}}}
+
+	admin := admintest.New(nil)
+	res, err := topicctl.ImportTopics(context.Background(), admin, file, topicctl.ImportOptions{})
+	if err == nil || len(res.Failed) != 1 || len(admin.Calls()) != 0 {
+		t.Fatalf("default: err = %v, failed = %v, calls = %q; want one failure and no calls", err, res.Failed, admin.Calls())
+	}
+
+	admin = admintest.New(nil)
+	res, err = topicctl.ImportTopics(context.Background(), admin, file, topicctl.ImportOptions{AllowUnspecified: true})
+	if err != nil || !slices.Equal(res.Created, []string{"orders"}) {
+		t.Fatalf("AllowUnspecified: err = %v, created = %v; want orders", err, res.Created)
+	}
+}
@@ -0,0 +1,36 @@
+package topicctl
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// LatencySummary 汇总 ImportOptions.Timing 时记录的创建请求耗时；批量创建时批内每个 topic 记为整批的耗时
+type LatencySummary struct {
+	Count int           `json:"count"`
+	Min   time.Duration `json:"min_ns"`
+	Max   time.Duration `json:"max_ns"`
+	P50   time.Duration `json:"p50_ns"`
+	P95   time.Duration `json:"p95_ns"`
+}
+
+// summarizeLatency 计算耗时的最小值、最大值和 p50 / p95（nearest-rank），没有数据时返回 nil
+func summarizeLatency(durations []time.Duration) *LatencySummary {
+	if len(durations) == 0 {
+		return nil
+	}
+	sorted := append([]time.Duration(nil), durations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	rank := func(p float64) time.Duration {
+		return sorted[int(math.Ceil(p*float64(len(sorted))))-1]
+	}
+	return &LatencySummary{
+		Count: len(sorted),
+		Min:   sorted[0],
+		Max:   sorted[len(sorted)-1],
+		P50:   rank(0.5),
+		P95:   rank(0.95),
+	}
+}
@@ -0,0 +1,25 @@
+package topicctl
+
+import "sort"
+
+// MergeFiles 把 fresh 中的 topic 按名称合并进 base：同名的以 fresh 为准，base 中其余 topic 保留，结果按名称排序；
+// 文件头（Kafka 版本、导出时间）取 fresh 的
+func MergeFiles(base, fresh *ExportFile) *ExportFile {
+	out := *fresh
+	out.Topics = append([]Topic(nil), fresh.Topics...)
+
+	exported := make(map[string]bool, len(fresh.Topics))
+	for _, t := range fresh.Topics {
+		exported[t.Name] = true
+	}
+	for _, t := range base.Topics {
+		if !exported[t.Name] {
+			out.Topics = append(out.Topics, t)
+		}
+	}
+
+	sort.Slice(out.Topics, func(i, j int) bool {
+		return out.Topics[i].Name < out.Topics[j].Name
+	})
+	return &out
+}
@@ -0,0 +1,198 @@
+package topicctl
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// FormatNDJSON 是每行一个 Topic JSON 对象的格式（JSON Lines），可以边查询边写出、逐行读取；
+// 不记录 kafka_version / export_time
+const FormatNDJSON = "ndjson"
+
+// loadNDJSON 逐行解析 NDJSON 文件，空行忽略
+func loadNDJSON(in string) (*ExportFile, error) {
+	file := &ExportFile{}
+	err := eachLine(in, func(n int, line []byte) error {
+		var t Topic
+		if err := json.Unmarshal(line, &t); err != nil {
+			return fmt.Errorf("解析 %s 第 %d 行失败: %w", in, n, err)
+		}
+		file.Topics = append(file.Topics, t)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+// eachLine 以流的方式读取输入，对每个非空行调用 fn，行号从 1 开始；单行长度不受限制
+func eachLine(in string, fn func(n int, line []byte) error) error {
+	r, err := openInput(in)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	br := bufio.NewReader(r)
+	for n := 1; ; n++ {
+		line, err := br.ReadBytes('\n')
+		if len(bytes.TrimSpace(line)) > 0 {
+			if fnErr := fn(n, line); fnErr != nil {
+				return fnErr
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("读取 %s 失败: %w", in, err)
+		}
+	}
+}
+
+// openInput 以流的方式打开输入文件，gzip / zstd 压缩的内容按 magic bytes 自动解压；
+// stdin 可能已被 --strict 的 schema 校验读过，因此仍走 readInput 的缓存
+func openInput(in string) (io.ReadCloser, error) {
+	if in == Stdio {
+		data, err := readInput(in)
+		if err != nil {
+			return nil, err
+		}
+		return io.NopCloser(bytes.NewReader(data)), nil
+	}
+
+	f, err := os.Open(in)
+	if err != nil {
+		return nil, err
+	}
+	br := bufio.NewReader(f)
+	magic, _ := br.Peek(len(zstdMagic))
+	switch {
+	case bytes.HasPrefix(magic, gzipMagic):
+		zr, err := gzip.NewReader(br)
+		if err != nil {
+			f.Close()
+			return nil, fmt.Errorf("gzip 解压失败: %w", err)
+		}
+		return readCloser{zr, func() error { zr.Close(); return f.Close() }}, nil
+	case bytes.HasPrefix(magic, zstdMagic):
+		zr, err := zstd.NewReader(br)
+		if err != nil {
+			f.Close()
+			return nil, err
+		}
+		return readCloser{zr, func() error { zr.Close(); return f.Close() }}, nil
+	}
+	return readCloser{br, f.Close}, nil
+}
+
+// readCloser 组合解压 reader 与关闭底层文件的函数
+type readCloser struct {
+	io.Reader
+	close func() error
+}
+
+// Close 关闭解压 reader 和底层文件
+func (r readCloser) Close() error {
+	return r.close()
+}
+
+// writeNDJSON 把每个 topic 编码为一行 JSON 写出
+func writeNDJSON(w io.Writer, topics []Topic) error {
+	enc := json.NewEncoder(w)
+	for _, t := range topics {
+		if err := enc.Encode(t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// TopicWriter 把 topic 逐个以 NDJSON 写出，不在内存中保留已写出的 topic
+type TopicWriter struct {
+	enc   *json.Encoder
+	buf   *bufio.Writer
+	comp  io.WriteCloser // 压缩 writer，不压缩时为 nil
+	file  *os.File       // 写到 stdout 时为 nil
+	count int
+}
+
+// NewTopicWriter 创建 out 的 NDJSON writer；out 为 - 时写到 stdout，compression 非空时边写边压缩
+func NewTopicWriter(out, compression string) (*TopicWriter, error) {
+	tw := &TopicWriter{}
+	var w io.Writer = os.Stdout
+	if out != Stdio {
+		f, err := os.Create(out)
+		if err != nil {
+			return nil, err
+		}
+		tw.file, w = f, f
+	}
+
+	switch compression {
+	case CompressNone:
+	case CompressGzip:
+		tw.comp = gzip.NewWriter(w)
+	case CompressZstd:
+		zw, err := zstd.NewWriter(w)
+		if err != nil {
+			tw.closeFile()
+			return nil, err
+		}
+		tw.comp = zw
+	default:
+		tw.closeFile()
+		return nil, fmt.Errorf("不支持的压缩方式 %q（可选 gzip / zstd）", compression)
+	}
+	if tw.comp != nil {
+		w = tw.comp
+	}
+
+	tw.buf = bufio.NewWriter(w)
+	tw.enc = json.NewEncoder(tw.buf)
+	return tw, nil
+}
+
+// Write 写出一个 topic
+func (w *TopicWriter) Write(t Topic) error {
+	if err := w.enc.Encode(t); err != nil {
+		return err
+	}
+	w.count++
+	return nil
+}
+
+// Count 返回已写出的 topic 数
+func (w *TopicWriter) Count() int {
+	return w.count
+}
+
+// Close 刷新缓冲、结束压缩流并关闭文件
+func (w *TopicWriter) Close() error {
+	err := w.buf.Flush()
+	if w.comp != nil {
+		if cErr := w.comp.Close(); err == nil {
+			err = cErr
+		}
+	}
+	if cErr := w.closeFile(); err == nil {
+		err = cErr
+	}
+	return err
+}
+
+// closeFile 关闭输出文件，写到 stdout 时不做任何事
+func (w *TopicWriter) closeFile() error {
+	if w.file == nil {
+		return nil
+	}
+	return w.file.Close()
+}
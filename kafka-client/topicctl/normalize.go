@@ -0,0 +1,42 @@
+package topicctl
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// Normalize 把文件整理为规范形式：topic 按名称排序，空的配置 map 去掉，
+// 完全相同的重复 topic 只保留一份；同名但内容不同的 topic 返回错误。
+// 配置项 key 的顺序由序列化时对 map 排序保证
+func Normalize(file *ExportFile) (*ExportFile, error) {
+	out := *file
+	out.Topics = nil
+
+	byName := make(map[string]int, len(file.Topics))
+	for i, t := range file.Topics {
+		if len(t.Configs) == 0 {
+			t.Configs = nil
+		}
+		if len(t.ReplicaAssignment) == 0 {
+			t.ReplicaAssignment = nil
+		}
+		if len(t.EffectiveConfigs) == 0 {
+			t.EffectiveConfigs = nil
+		}
+
+		if j, ok := byName[t.Name]; ok {
+			if !reflect.DeepEqual(out.Topics[j], t) {
+				return nil, fmt.Errorf("topics[%d] (%s) 与前面的同名 topic 内容不一致，无法去重", i, t.Name)
+			}
+			continue
+		}
+		byName[t.Name] = len(out.Topics)
+		out.Topics = append(out.Topics, t)
+	}
+
+	sort.Slice(out.Topics, func(i, j int) bool {
+		return out.Topics[i].Name < out.Topics[j].Name
+	})
+	return &out, nil
+}
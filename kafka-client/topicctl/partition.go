@@ -0,0 +1,93 @@
+package topicctl
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/IBM/sarama"
+)
+
+// PartitionDetail 是 --include-partition-detail 时记录的分区元数据，用于备份和事后分析；
+// 导入时忽略（无法指定 leader），diff 据此发现 leader / ISR 的变化
+type PartitionDetail struct {
+	ID       int32   `json:"id" yaml:"id"`
+	Leader   int32   `json:"leader" yaml:"leader"`
+	Replicas []int32 `json:"replicas" yaml:"replicas"`
+	ISR      []int32 `json:"isr" yaml:"isr"`
+}
+
+// partitionDetailBatch 是每次 DescribeTopics 查询的 topic 数，避免大集群的单个元数据请求过大
+const partitionDetailBatch = 500
+
+// FillPartitionDetail 通过 DescribeTopics 查询 topics 每个分区的 leader、副本和 ISR，写入 PartitionDetail
+func FillPartitionDetail(admin Admin, topics []Topic) error {
+	for start := 0; start < len(topics); start += partitionDetailBatch {
+		batch := topics[start:min(start+partitionDetailBatch, len(topics))]
+		names := make([]string, len(batch))
+		for i, t := range batch {
+			names[i] = t.Name
+		}
+
+		metas, err := admin.DescribeTopics(names)
+		if err != nil {
+			return fmt.Errorf("查询分区元数据失败: %w", err)
+		}
+		byName := make(map[string]*sarama.TopicMetadata, len(metas))
+		for _, m := range metas {
+			byName[m.Name] = m
+		}
+
+		for i := range batch {
+			meta, ok := byName[batch[i].Name]
+			if !ok {
+				return fmt.Errorf("查询 topic %s 的分区元数据失败: broker 未返回该 topic", batch[i].Name)
+			}
+			if meta.Err != sarama.ErrNoError {
+				return fmt.Errorf("查询 topic %s 的分区元数据失败: %w", batch[i].Name, meta.Err)
+			}
+			batch[i].PartitionDetail = partitionDetail(meta)
+		}
+	}
+	return nil
+}
+
+// partitionDetail 把 topic 元数据转换为按分区号排序的 PartitionDetail
+func partitionDetail(meta *sarama.TopicMetadata) []PartitionDetail {
+	details := make([]PartitionDetail, len(meta.Partitions))
+	for i, p := range meta.Partitions {
+		details[i] = PartitionDetail{ID: p.ID, Leader: p.Leader, Replicas: p.Replicas, ISR: p.Isr}
+	}
+	sort.Slice(details, func(i, j int) bool { return details[i].ID < details[j].ID })
+	return details
+}
+
+// diffPartitionDetail 对比两侧都记录了分区元数据时每个分区的 leader、副本和 ISR
+func diffPartitionDetail(have, want []PartitionDetail) []FieldChange {
+	if len(have) == 0 || len(want) == 0 {
+		return nil
+	}
+
+	haveByID := make(map[int32]PartitionDetail, len(have))
+	for _, p := range have {
+		haveByID[p.ID] = p
+	}
+	var changes []FieldChange
+	for _, w := range want {
+		h, ok := haveByID[w.ID]
+		field := fmt.Sprintf("partition_detail[%d]", w.ID)
+		if !ok {
+			changes = append(changes, FieldChange{Field: field, Old: "<未设置>", New: fmt.Sprintf("leader=%d", w.Leader)})
+			continue
+		}
+		if h.Leader != w.Leader {
+			changes = append(changes, FieldChange{Field: field + ".leader", Old: fmt.Sprint(h.Leader), New: fmt.Sprint(w.Leader)})
+		}
+		if fmt.Sprint(h.Replicas) != fmt.Sprint(w.Replicas) {
+			changes = append(changes, FieldChange{Field: field + ".replicas", Old: fmt.Sprint(h.Replicas), New: fmt.Sprint(w.Replicas)})
+		}
+		if fmt.Sprint(h.ISR) != fmt.Sprint(w.ISR) {
+			changes = append(changes, FieldChange{Field: field + ".isr", Old: fmt.Sprint(h.ISR), New: fmt.Sprint(w.ISR)})
+		}
+	}
+	return changes
+}
@@ -0,0 +1,34 @@
+package topicctl
+
+import "sort"
+
+// Redacted 是导出时 --redact 配置项的占位值；导入、对比和修改配置时遇到它都会跳过该配置项
+const Redacted = "***REDACTED***"
+
+// redactConfigs 把 keys 中配置项的值替换为 Redacted，包括 EffectiveConfigs 中的记录
+func redactConfigs(topics []Topic, keys []string) {
+	for i := range topics {
+		t := &topics[i]
+		for _, k := range keys {
+			if _, ok := t.Configs[k]; ok {
+				t.Configs[k] = Redacted
+			}
+			if v, ok := t.EffectiveConfigs[k]; ok {
+				v.Value = Redacted
+				t.EffectiveConfigs[k] = v
+			}
+		}
+	}
+}
+
+// redactedKeys 返回 topic 中值为 Redacted 的配置项，按名称排序
+func redactedKeys(t Topic) []string {
+	var keys []string
+	for k, v := range t.Configs {
+		if v == Redacted {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
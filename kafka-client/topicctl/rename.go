@@ -0,0 +1,76 @@
+package topicctl
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// NameTransform 描述导入时对 topic 名称的改写，依次执行去前缀、去后缀、加前缀、加后缀
+type NameTransform struct {
+	StripPrefix string
+	StripSuffix string
+	AddPrefix   string
+	AddSuffix   string
+}
+
+// Empty 判断是否没有任何改写
+func (n NameTransform) Empty() bool {
+	return n == NameTransform{}
+}
+
+// Apply 返回改写后的名称；不带指定前缀/后缀的名称不做去除
+func (n NameTransform) Apply(name string) string {
+	name = strings.TrimPrefix(name, n.StripPrefix)
+	name = strings.TrimSuffix(name, n.StripSuffix)
+	return n.AddPrefix + name + n.AddSuffix
+}
+
+// renameTopics 按 opts.Rename 改写每个 topic 的名称并记录 原名 -> 新名；
+// 不同的原名改写后相同时不做任何改写，返回列出全部冲突的错误，避免只创建其中一个
+func renameTopics(file *ExportFile, opts *ImportOptions) (*ExportFile, error) {
+	if err := checkCollisions(file, opts.Rename); err != nil {
+		return nil, err
+	}
+
+	out := *file
+	out.Topics = make([]Topic, len(file.Topics))
+	for i, t := range file.Topics {
+		if name := opts.Rename.Apply(t.Name); name != t.Name {
+			detail := fmt.Sprintf("%s -> %s", t.Name, name)
+			opts.log(Event{
+				Action: "rename", Topic: name, Status: "renamed", Detail: detail,
+				Message: fmt.Sprintf("🏷️  改名: %s", detail),
+			})
+			t.Name = name
+		}
+		out.Topics[i] = t
+	}
+	return &out, nil
+}
+
+// checkCollisions 检查改写后是否有多个不同的原名得到同一个新名
+func checkCollisions(file *ExportFile, rename NameTransform) error {
+	sources := make(map[string][]string)
+	var order []string
+	for _, t := range file.Topics {
+		name := rename.Apply(t.Name)
+		if _, ok := sources[name]; !ok {
+			order = append(order, name)
+		}
+		if !slices.Contains(sources[name], t.Name) {
+			sources[name] = append(sources[name], t.Name)
+		}
+	}
+
+	var collisions []string
+	for _, name := range order {
+		if src := sources[name]; len(src) > 1 {
+			collisions = append(collisions, fmt.Sprintf("%s -> %s", strings.Join(src, ", "), name))
+		}
+	}
+	if len(collisions) > 0 {
+		return fmt.Errorf("改名后有 %d 组 topic 名称冲突，未创建任何 topic:\n  %s", len(collisions), strings.Join(collisions, "\n  "))
+	}
+	return nil
+}
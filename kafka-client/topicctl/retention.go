@@ -0,0 +1,52 @@
+package topicctl
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// RetentionRange 按 topic 的 retention.ms 覆盖项筛选；Min/Max 为 0 表示该侧不限，retention.ms=-1（永久保留）视为无穷大
+type RetentionRange struct {
+	Min            int64
+	Max            int64
+	IncludeDefault bool // 没有 retention.ms 覆盖项（使用 broker 默认值）的 topic 是否保留
+}
+
+// Active 判断是否设置了任何保留时间条件
+func (r *RetentionRange) Active() bool {
+	return r != nil && (r.Min != 0 || r.Max != 0)
+}
+
+// filterRetention 返回 retention.ms 落在范围内的 topic；值无法解析时通过 log 告警并剔除该 topic
+func filterRetention(topics []Topic, r *RetentionRange, log func(Event)) []Topic {
+	var kept []Topic
+	for _, t := range topics {
+		raw, ok := t.Configs["retention.ms"]
+		if !ok {
+			if r.IncludeDefault {
+				kept = append(kept, t)
+			}
+			continue
+		}
+
+		ms, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			if log != nil {
+				log(Event{
+					Action: "filter-retention", Topic: t.Name, Status: "warning", Detail: raw,
+					Message: fmt.Sprintf("⚠️  topic %s 的 retention.ms=%q 无法解析，已跳过", t.Name, raw),
+				})
+			}
+			continue
+		}
+		if ms < 0 {
+			ms = math.MaxInt64
+		}
+		if (r.Min != 0 && ms < r.Min) || (r.Max != 0 && ms > r.Max) {
+			continue
+		}
+		kept = append(kept, t)
+	}
+	return kept
+}
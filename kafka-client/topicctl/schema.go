@@ -0,0 +1,274 @@
+package topicctl
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"math"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Schema 是导出文件的 JSON Schema，描述 ExportFile / Topic 的结构
+//
+//go:embed schema.json
+var Schema []byte
+
+// schemaNode 是 JSON Schema 中本工具用到的子集
+type schemaNode struct {
+	Type                 string                 `json:"type"`
+	Required             []string               `json:"required"`
+	Properties           map[string]*schemaNode `json:"properties"`
+	AdditionalProperties *additionalProps       `json:"additionalProperties"`
+	Items                *schemaNode            `json:"items"`
+	Minimum              *float64               `json:"minimum"`
+	Maximum              *float64               `json:"maximum"`
+	MinLength            *int                   `json:"minLength"`
+	MaxLength            *int                   `json:"maxLength"`
+	Pattern              string                 `json:"pattern"`
+}
+
+// additionalProps 对应 additionalProperties，可以是 false 或一个子 schema
+type additionalProps struct {
+	Forbidden bool
+	Schema    *schemaNode
+}
+
+// UnmarshalJSON 解析 additionalProperties 的布尔或对象形式
+func (a *additionalProps) UnmarshalJSON(data []byte) error {
+	var b bool
+	if err := json.Unmarshal(data, &b); err == nil {
+		a.Forbidden = !b
+		return nil
+	}
+	return json.Unmarshal(data, &a.Schema)
+}
+
+// loadSchema 解析内嵌 schema，只解析一次；返回整个文件的 schema 和单个 topic 的 schema。
+// schema 与结构体字段是否一致由 schema_test.go 检查
+var loadSchema = sync.OnceValues(func() (*schemaNode, error) {
+	var s schemaNode
+	if err := json.Unmarshal(Schema, &s); err != nil {
+		return nil, fmt.Errorf("内嵌 schema 无效: %w", err)
+	}
+	if topics := s.Properties["topics"]; topics == nil || topics.Items == nil {
+		return nil, fmt.Errorf("内嵌 schema 无效: 缺少 topics.items")
+	}
+	return &s, nil
+})
+
+// CheckSchema 在解析为结构体之前，用内嵌的 JSON Schema 校验文件，返回发现的全部问题；
+// in 为目录时逐个校验其中的单 topic 文件，问题前加上文件名
+func CheckSchema(in, format string) ([]string, error) {
+	return CheckSchemaWith(in, format, ValidateOptions{})
+}
+
+// CheckSchemaWith 与 CheckSchema 相同；opts.AllowUnspecified 时 partitions / replication_factor 可以省略或为 0
+func CheckSchemaWith(in, format string, opts ValidateOptions) ([]string, error) {
+	root, err := loadSchema()
+	if err != nil {
+		return nil, err
+	}
+	if opts.AllowUnspecified {
+		root = allowUnspecified(root)
+	}
+	topic := root.Properties["topics"].Items
+
+	if !isDir(in) {
+		var problems []string
+		err := checkDocument(in, format, root, topic, &problems)
+		return problems, err
+	}
+
+	files, err := dirFiles(in)
+	if err != nil {
+		return nil, err
+	}
+	var problems []string
+	for _, path := range files {
+		var fileProblems []string
+		if err := checkDocument(path, format, topic, topic, &fileProblems); err != nil {
+			return nil, err
+		}
+		for _, p := range fileProblems {
+			problems = append(problems, filepath.Base(path)+": "+p)
+		}
+	}
+	return problems, nil
+}
+
+// allowUnspecified 返回 root 的副本，其中 topic 的 partitions / replication_factor 不再必填且允许为 0；不修改 root
+func allowUnspecified(root *schemaNode) *schemaNode {
+	topic := *root.Properties["topics"].Items
+	topic.Required = nil
+	for _, key := range root.Properties["topics"].Items.Required {
+		if key != "partitions" && key != "replication_factor" {
+			topic.Required = append(topic.Required, key)
+		}
+	}
+	topic.Properties = maps.Clone(topic.Properties)
+	zero := 0.0
+	for _, key := range []string{"partitions", "replication_factor"} {
+		field := *topic.Properties[key]
+		field.Minimum = &zero
+		topic.Properties[key] = &field
+	}
+
+	topics := *root.Properties["topics"]
+	topics.Items = &topic
+	out := *root
+	out.Properties = maps.Clone(root.Properties)
+	out.Properties["topics"] = &topics
+	return &out
+}
+
+// checkDocument 读取单个文件并按 schema 校验；NDJSON 文件逐行按单个 topic 的 schema topic 校验
+func checkDocument(in, format string, schema, topic *schemaNode, problems *[]string) error {
+	format, err := ResolveFormat(in, format)
+	if err != nil {
+		return err
+	}
+	if format == FormatNDJSON {
+		return checkNDJSON(in, topic, problems)
+	}
+
+	data, err := readInput(in)
+	if err != nil {
+		return err
+	}
+
+	var doc any
+	if format == "yaml" {
+		// 先按 yaml 解析，再转成 JSON 数据模型，让两种格式的数字、对象类型一致
+		var raw any
+		if err := yaml.Unmarshal(data, &raw); err != nil {
+			return fmt.Errorf("解析 %s 失败: %w", in, err)
+		}
+		if data, err = json.Marshal(raw); err != nil {
+			return fmt.Errorf("解析 %s 失败: %w", in, err)
+		}
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("解析 %s 失败: %w", in, err)
+	}
+
+	schema.check("", doc, problems)
+	return nil
+}
+
+// checkNDJSON 逐行按单个 topic 的 schema 校验 NDJSON 文件，问题按 第 3 行.partitions 形式的路径记录
+func checkNDJSON(in string, schema *schemaNode, problems *[]string) error {
+	return eachLine(in, func(n int, line []byte) error {
+		var doc any
+		if err := json.Unmarshal(line, &doc); err != nil {
+			return fmt.Errorf("解析 %s 第 %d 行失败: %w", in, n, err)
+		}
+		schema.check(fmt.Sprintf("第 %d 行", n), doc, problems)
+		return nil
+	})
+}
+
+// check 递归校验 v 是否符合 schema，问题按 topics[3].partitions 形式的路径记录
+func (s *schemaNode) check(path string, v any, problems *[]string) {
+	where := path
+	if where == "" {
+		where = "文件"
+	}
+	report := func(format string, args ...any) {
+		*problems = append(*problems, where+" "+fmt.Sprintf(format, args...))
+	}
+
+	if s.Type != "" && jsonType(v, s.Type) != s.Type {
+		report("类型应为 %s，当前为 %s", s.Type, jsonType(v, s.Type))
+		return
+	}
+
+	switch v := v.(type) {
+	case map[string]any:
+		for _, key := range s.Required {
+			if _, ok := v[key]; !ok {
+				*problems = append(*problems, joinPath(path, key)+" 为必填字段")
+			}
+		}
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if sub, ok := s.Properties[k]; ok {
+				sub.check(joinPath(path, k), v[k], problems)
+				continue
+			}
+			if ap := s.AdditionalProperties; ap != nil {
+				if ap.Forbidden {
+					*problems = append(*problems, joinPath(path, k)+" 不是允许的字段")
+				} else if ap.Schema != nil {
+					ap.Schema.check(joinPath(path, k), v[k], problems)
+				}
+			}
+		}
+
+	case []any:
+		if s.Items != nil {
+			for i, item := range v {
+				s.Items.check(fmt.Sprintf("%s[%d]", path, i), item, problems)
+			}
+		}
+
+	case float64:
+		if s.Minimum != nil && v < *s.Minimum {
+			report("必须 >= %v，当前为 %v", *s.Minimum, v)
+		}
+		if s.Maximum != nil && v > *s.Maximum {
+			report("必须 <= %v，当前为 %v", *s.Maximum, v)
+		}
+
+	case string:
+		n := len(v)
+		if s.MinLength != nil && n < *s.MinLength {
+			report("长度必须 >= %d，当前为 %d", *s.MinLength, n)
+		}
+		if s.MaxLength != nil && n > *s.MaxLength {
+			report("长度必须 <= %d，当前为 %d", *s.MaxLength, n)
+		}
+		if s.Pattern != "" && n > 0 && !regexp.MustCompile(s.Pattern).MatchString(v) {
+			report("不匹配 %s", s.Pattern)
+		}
+	}
+}
+
+// jsonType 返回 v 的 JSON Schema 类型名；want 为 integer 时整数值按 integer 处理
+func jsonType(v any, want string) string {
+	switch v := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		if want == "integer" && v == math.Trunc(v) {
+			return "integer"
+		}
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+// joinPath 拼接对象字段路径
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
@@ -0,0 +1,62 @@
+package topicctl
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// schemaMismatches 对比结构体的 json 字段与 schema 的 properties，返回两边不一致的字段
+func schemaMismatches(name string, t reflect.Type, node *schemaNode) []string {
+	var mismatches []string
+	fields := make(map[string]bool)
+	for i := 0; i < t.NumField(); i++ {
+		key, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if key == "" || key == "-" {
+			continue
+		}
+		fields[key] = true
+		if _, ok := node.Properties[key]; !ok {
+			mismatches = append(mismatches, fmt.Sprintf("%s.%s 不在 schema 中", name, key))
+		}
+	}
+	for key := range node.Properties {
+		if !fields[key] {
+			mismatches = append(mismatches, fmt.Sprintf("schema 中的 %s 在 %s 中没有对应字段", key, name))
+		}
+	}
+	sort.Strings(mismatches)
+	return mismatches
+}
+
+// 内嵌 schema 必须随结构体一起更新
+func TestSchemaMatchesStructs(t *testing.T) {
+	root, err := loadSchema()
+	if err != nil {
+		t.Fatal(err)
+	}
+	topic := root.Properties["topics"].Items
+
+	tests := []struct {
+		name string
+		typ  reflect.Type
+		node *schemaNode
+	}{
+		{"ExportFile", reflect.TypeOf(ExportFile{}), root},
+		{"Topic", reflect.TypeOf(Topic{}), topic},
+		{"ConfigValue", reflect.TypeOf(ConfigValue{}), topic.Properties["effective_configs"].AdditionalProperties.Schema},
+		{"PartitionDetail", reflect.TypeOf(PartitionDetail{}), topic.Properties["partition_detail"].Items},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.node == nil {
+				t.Fatalf("schema 中没有 %s 的定义", tt.name)
+			}
+			for _, m := range schemaMismatches(tt.name, tt.typ, tt.node) {
+				t.Error(m)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+// Package topicctl 提供 Kafka topic 导出、导入、对比和校验的核心逻辑，
+// 既供 kafka-topicctl 命令行使用，也可以直接嵌入其他 Go 程序。
+package topicctl
+
+import (
+	"strings"
+
+	"github.com/IBM/sarama"
+)
+
+// Topic 是导出/导入的 JSON 结构。
+// Configs 虽然是 map，但 encoding/json 和 yaml.v3 序列化 map 时都会按 key 排序，
+// 因此导出结果的配置项顺序是稳定的，导入时也能原样读回，无需额外的有序结构。
+// Partitions / ReplicationFactor 为 0 表示未指定（如 --only-configs 导出的文件），只有启用 AllowUnspecified 时才允许，
+// 创建时使用 broker 默认值（需要 Kafka >= 2.4）。
+type Topic struct {
+	Name              string            `json:"name" yaml:"name"`
+	Partitions        int32             `json:"partitions,omitempty" yaml:"partitions,omitempty"`
+	ReplicationFactor int16             `json:"replication_factor,omitempty" yaml:"replication_factor,omitempty"`
+	Configs           map[string]string `json:"configs,omitempty" yaml:"configs,omitempty"`
+
+	// ReplicaAssignment 可选，显式指定每个分区的副本所在 broker（分区号 -> broker ID 列表），
+	// 分区号必须从 0 开始连续；指定后创建时由它决定分区数和副本数
+	ReplicaAssignment map[int32][]int32 `json:"replica_assignment,omitempty" yaml:"replica_assignment,omitempty"`
+
+	// NewPartitionAssignment 可选，--alter-partitions 扩容已存在的 topic 时新增分区的副本所在 broker，
+	// 按新增分区的顺序排列；未指定时由 broker 决定
+	NewPartitionAssignment [][]int32 `json:"new_partition_assignment,omitempty" yaml:"new_partition_assignment,omitempty"`
+
+	// EffectiveConfigs 是 --include-defaults 时记录的全部生效配置，仅用于文档；
+	// 导入只应用 Configs 中的覆盖项，不会把默认值写回集群
+	EffectiveConfigs map[string]ConfigValue `json:"effective_configs,omitempty" yaml:"effective_configs,omitempty"`
+
+	// PartitionDetail 是 --include-partition-detail 时记录的每个分区的 leader、副本和 ISR，导入时忽略
+	PartitionDetail []PartitionDetail `json:"partition_detail,omitempty" yaml:"partition_detail,omitempty"`
+}
+
+// ConfigValue 是一个生效配置的值及其来源
+type ConfigValue struct {
+	Value   string `json:"value" yaml:"value"`
+	Source  string `json:"source" yaml:"source"`   // sarama.ConfigSource 的名称，如 Topic、Default
+	Default bool   `json:"default" yaml:"default"` // 是否为默认值（非 topic 级覆盖）
+}
+
+// ExportFile 是整个导出文件的结构
+type ExportFile struct {
+	KafkaVersion string  `json:"kafka_version" yaml:"kafka_version"`
+	ExportTime   string  `json:"export_time,omitempty" yaml:"export_time,omitempty"` // 为空表示不记录导出时间
+	Topics       []Topic `json:"topics" yaml:"topics"`
+}
+
+// IsInternal 判断是否为内部 topic（以 __ 开头）
+func IsInternal(name string) bool {
+	return strings.HasPrefix(name, "__")
+}
+
+// newTopic 把 sarama.TopicDetail 转换为 Topic，filter 剔除的配置项不会被复制
+func newTopic(name string, detail sarama.TopicDetail, filter *Filter) Topic {
+	// map[string]*string -> map[string]string
+	configs := make(map[string]string)
+	for k, v := range detail.ConfigEntries {
+		if !filter.KeepConfig(k) {
+			continue
+		}
+		if v != nil {
+			configs[k] = *v
+		} else {
+			configs[k] = ""
+		}
+	}
+
+	return Topic{
+		Name:              name,
+		Partitions:        detail.NumPartitions,
+		ReplicationFactor: detail.ReplicationFactor,
+		Configs:           configs,
+	}
+}
+
+// topicDetail 把 Topic 转换为 sarama.TopicDetail
+func topicDetail(t Topic) *sarama.TopicDetail {
+	// map[string]string -> map[string]*string
+	cfg := make(map[string]*string)
+	for k, v := range t.Configs {
+		if v == Redacted {
+			continue
+		}
+		vCopy := v // 避免取地址错误
+		cfg[k] = &vCopy
+	}
+
+	// 未指定的分区数和副本数传 -1，由 broker 使用 num.partitions / default.replication.factor
+	detail := &sarama.TopicDetail{
+		NumPartitions:     t.Partitions,
+		ReplicationFactor: t.ReplicationFactor,
+		ConfigEntries:     cfg,
+	}
+	// 显式指定副本分配时，sarama 要求 NumPartitions 和 ReplicationFactor 为 -1
+	if len(t.ReplicaAssignment) > 0 {
+		detail.NumPartitions = -1
+		detail.ReplicationFactor = -1
+		detail.ReplicaAssignment = t.ReplicaAssignment
+		return detail
+	}
+	if detail.NumPartitions == 0 {
+		detail.NumPartitions = -1
+	}
+	if detail.ReplicationFactor == 0 {
+		detail.ReplicationFactor = -1
+	}
+	return detail
+}
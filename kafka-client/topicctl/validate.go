@@ -0,0 +1,190 @@
+package topicctl
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/IBM/sarama"
+)
+
+// maxTopicNameLength 是 Kafka 允许的 topic 名称最大长度
+const maxTopicNameLength = 249
+
+// validTopicName 匹配 Kafka 允许的 topic 名称字符
+var validTopicName = regexp.MustCompile(`^[a-zA-Z0-9._-]+$`)
+
+// ValidateOptions 控制 ValidateWith / CheckSchemaWith 的检查规则
+type ValidateOptions struct {
+	// AllowUnspecified 允许 partitions / replication_factor 省略或为 0（如 --only-configs 导出的文件），
+	// 创建时由 broker 使用 num.partitions / default.replication.factor，需要 Kafka >= 2.4（KIP-464）
+	AllowUnspecified bool
+}
+
+// Validate 离线检查导出文件，返回发现的全部问题；partitions 和 replication_factor 必须 >= 1
+func Validate(file *ExportFile) []string {
+	return ValidateWith(file, ValidateOptions{})
+}
+
+// ValidateWith 与 Validate 相同，但按 opts 调整检查规则
+func ValidateWith(file *ExportFile, opts ValidateOptions) []string {
+	var problems []string
+	seen := make(map[string]int)
+
+	for i, t := range file.Topics {
+		where := fmt.Sprintf("topics[%d]", i)
+		if t.Name != "" {
+			where = fmt.Sprintf("topics[%d] (%s)", i, t.Name)
+		}
+		report := func(format string, args ...any) {
+			problems = append(problems, where+": "+fmt.Sprintf(format, args...))
+		}
+
+		switch {
+		case t.Name == "":
+			report("name 不能为空")
+		case t.Name == "." || t.Name == "..":
+			report("name 不能为 . 或 ..")
+		case len(t.Name) > maxTopicNameLength:
+			report("name 长度 %d 超过上限 %d", len(t.Name), maxTopicNameLength)
+		case !validTopicName.MatchString(t.Name):
+			report("name 只能包含字母、数字、'.'、'_' 和 '-'")
+		}
+
+		if t.Name != "" {
+			if first, ok := seen[t.Name]; ok {
+				report("name 与 topics[%d] 重复", first)
+			} else {
+				seen[t.Name] = i
+			}
+		}
+
+		// AllowUnspecified 时 0 表示未指定，创建时使用 broker 默认值
+		switch {
+		case opts.AllowUnspecified && t.Partitions < 0:
+			report("partitions 必须 >= 1 或为 0（未指定），当前为 %d", t.Partitions)
+		case !opts.AllowUnspecified && t.Partitions < 1:
+			report("partitions 必须 >= 1，当前为 %d", t.Partitions)
+		}
+		switch {
+		case opts.AllowUnspecified && t.ReplicationFactor < 0:
+			report("replication_factor 必须 >= 1 或为 0（未指定），当前为 %d", t.ReplicationFactor)
+		case !opts.AllowUnspecified && t.ReplicationFactor < 1:
+			report("replication_factor 必须 >= 1，当前为 %d", t.ReplicationFactor)
+		}
+
+		for _, p := range validateAssignment(t) {
+			report("%s", p)
+		}
+	}
+
+	return problems
+}
+
+// validateAssignment 检查 replica_assignment（未指定时不检查）：分区号从 0 连续、每个分区至少一个副本且不重复，
+// 并与 partitions / replication_factor（如果指定）一致；未指定 replication_factor 时每个分区的副本数必须相同
+func validateAssignment(t Topic) []string {
+	if len(t.ReplicaAssignment) == 0 {
+		return nil
+	}
+
+	var problems []string
+	n := int32(len(t.ReplicaAssignment))
+
+	partitions := make([]int32, 0, n)
+	for p := range t.ReplicaAssignment {
+		partitions = append(partitions, p)
+	}
+	sort.Slice(partitions, func(i, j int) bool { return partitions[i] < partitions[j] })
+
+	for i, p := range partitions {
+		if p != int32(i) {
+			problems = append(problems, fmt.Sprintf("replica_assignment 分区号必须从 0 开始连续，缺少分区 %d", i))
+			break
+		}
+	}
+
+	for _, p := range partitions {
+		replicas := t.ReplicaAssignment[p]
+		if len(replicas) == 0 {
+			problems = append(problems, fmt.Sprintf("replica_assignment 分区 %d 没有副本", p))
+			continue
+		}
+		seen := make(map[int32]bool, len(replicas))
+		for _, b := range replicas {
+			if seen[b] {
+				problems = append(problems, fmt.Sprintf("replica_assignment 分区 %d 的 broker %d 重复", p, b))
+			}
+			seen[b] = true
+		}
+		if t.ReplicationFactor > 0 && len(replicas) != int(t.ReplicationFactor) {
+			problems = append(problems, fmt.Sprintf("replica_assignment 分区 %d 有 %d 个副本，与 replication_factor %d 不一致",
+				p, len(replicas), t.ReplicationFactor))
+		}
+		if first := t.ReplicaAssignment[partitions[0]]; t.ReplicationFactor <= 0 && len(replicas) != len(first) {
+			problems = append(problems, fmt.Sprintf("replica_assignment 分区 %d 有 %d 个副本，与分区 %d 的 %d 个不一致，每个分区的副本数必须相同",
+				p, len(replicas), partitions[0], len(first)))
+		}
+	}
+
+	if t.Partitions > 0 && t.Partitions != n {
+		problems = append(problems, fmt.Sprintf("replica_assignment 有 %d 个分区，与 partitions %d 不一致", n, t.Partitions))
+	}
+	return problems
+}
+
+// CheckMinISR 找出 configs 中 min.insync.replicas 大于副本数的 topic，这类 topic 在 acks=all 时无法写入；
+// 副本数取 replication_factor，未指定时取 replica_assignment 第 0 个分区的副本数，都没有时不检查
+func CheckMinISR(file *ExportFile) []string {
+	var problems []string
+	for i, t := range file.Topics {
+		raw, ok := t.Configs["min.insync.replicas"]
+		if !ok || raw == Redacted {
+			continue
+		}
+		where := fmt.Sprintf("topics[%d] (%s)", i, t.Name)
+
+		minISR, err := strconv.Atoi(raw)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: min.insync.replicas=%q 不是整数", where, raw))
+			continue
+		}
+		rf := int(t.ReplicationFactor)
+		if rf == 0 {
+			rf = len(t.ReplicaAssignment[0])
+		}
+		if rf > 0 && minISR > rf {
+			problems = append(problems, fmt.Sprintf("%s: min.insync.replicas=%d 大于副本数 %d，topic 将无法以 acks=all 写入", where, minISR, rf))
+		}
+	}
+	return problems
+}
+
+// validateNewPartitions 检查扩容时的 new_partition_assignment（未指定时不检查）：
+// 条目数等于新增的分区数，每个分区的副本数等于 topic 当前的副本数且 broker 不重复
+func validateNewPartitions(t Topic, cur sarama.TopicDetail) []string {
+	if len(t.NewPartitionAssignment) == 0 {
+		return nil
+	}
+
+	var problems []string
+	if added := int(t.Partitions - cur.NumPartitions); len(t.NewPartitionAssignment) != added {
+		problems = append(problems, fmt.Sprintf("有 %d 个条目，但新增分区数为 %d（%d -> %d）",
+			len(t.NewPartitionAssignment), added, cur.NumPartitions, t.Partitions))
+	}
+	for i, replicas := range t.NewPartitionAssignment {
+		p := cur.NumPartitions + int32(i)
+		if len(replicas) != int(cur.ReplicationFactor) {
+			problems = append(problems, fmt.Sprintf("分区 %d 有 %d 个副本，与当前副本数 %d 不一致", p, len(replicas), cur.ReplicationFactor))
+		}
+		seen := make(map[int32]bool, len(replicas))
+		for _, b := range replicas {
+			if seen[b] {
+				problems = append(problems, fmt.Sprintf("分区 %d 的 broker %d 重复", p, b))
+			}
+			seen[b] = true
+		}
+	}
+	return problems
+}
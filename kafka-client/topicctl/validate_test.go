@@ -0,0 +1,70 @@
+package topicctl
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestValidatePartitionsAndReplicationFactor(t *testing.T) {
+	tests := []struct {
+		name  string
+		topic Topic
+		opts  ValidateOptions
+		want  []string
+	}{
+		{name: "specified", topic: Topic{Name: "a", Partitions: 3, ReplicationFactor: 2}},
+		{
+			name:  "unspecified rejected by default",
+			topic: Topic{Name: "a"},
+			want: []string{
+				"topics[0] (a): partitions 必须 >= 1，当前为 0",
+				"topics[0] (a): replication_factor 必须 >= 1，当前为 0",
+			},
+		},
+		{name: "unspecified allowed", topic: Topic{Name: "a"}, opts: ValidateOptions{AllowUnspecified: true}},
+		{
+			name:  "negative rejected when unspecified allowed",
+			topic: Topic{Name: "a", Partitions: -1, ReplicationFactor: 1},
+			opts:  ValidateOptions{AllowUnspecified: true},
+			want:  []string{"topics[0] (a): partitions 必须 >= 1 或为 0（未指定），当前为 -1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ValidateWith(&ExportFile{Topics: []Topic{tt.topic}}, tt.opts)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("ValidateWith = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckSchemaUnspecified(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "topics.json")
+	if err := os.WriteFile(path, []byte(`{"topics": [{"name": "a"}]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	problems, err := CheckSchema(path, "auto")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(problems) != 2 {
+		t.Errorf("CheckSchema = %q, want missing partitions and replication_factor", problems)
+	}
+
+	problems, err = CheckSchemaWith(path, "auto", ValidateOptions{AllowUnspecified: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(problems) != 0 {
+		t.Errorf("CheckSchemaWith(AllowUnspecified) = %q, want none", problems)
+	}
+
+	// 宽松规则只作用于副本，不改动内嵌 schema
+	if problems, _ := CheckSchema(path, "auto"); len(problems) != 2 {
+		t.Errorf("CheckSchema after CheckSchemaWith = %q, want 2 problems", problems)
+	}
+}
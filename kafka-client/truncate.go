@@ -0,0 +1,142 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/IBM/sarama"
+)
+
+// parsePartitionOffsets 解析 --before-offset：单个数字表示所有分区，或 分区=offset 列表，如 0=100,1=200
+func parsePartitionOffsets(s string, partitions []int32) (map[int32]int64, error) {
+	offsets := make(map[int32]int64)
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		for _, p := range partitions {
+			offsets[p] = n
+		}
+		return offsets, nil
+	}
+
+	for _, item := range splitList(s) {
+		p, o, ok := strings.Cut(item, "=")
+		if !ok {
+			return nil, fmt.Errorf("无效的 --before-offset %q，应为 offset 或 分区=offset 列表", item)
+		}
+		partition, err := strconv.ParseInt(p, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("无效的分区号 %q: %w", p, err)
+		}
+		offset, err := strconv.ParseInt(o, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("无效的 offset %q: %w", o, err)
+		}
+		offsets[int32(partition)] = offset
+	}
+	return offsets, nil
+}
+
+// parseTimestamp 解析 --before-timestamp：RFC3339 时间或毫秒时间戳
+func parseTimestamp(s string) (int64, error) {
+	if ms, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return ms, nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return 0, fmt.Errorf("无效的 --before-timestamp %q，应为 RFC3339 时间或毫秒时间戳", s)
+	}
+	return t.UnixMilli(), nil
+}
+
+// truncateTopic 删除 topic 各分区中目标 offset 之前的消息；目标由 beforeOffset 指定，
+// 或由 beforeTimestamp 换算为该时间之后第一条消息的 offset。目标会被限制在 [最早 offset, 最新 offset] 之间
+func truncateTopic(conn *Config, topic, beforeOffset, beforeTimestamp string) error {
+	if err := checkDeletable(conn, []string{topic}); err != nil {
+		return err
+	}
+
+	client, err := newClient(conn)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	partitions, err := client.Partitions(topic)
+	if err != nil {
+		return fmt.Errorf("查询 topic %s 的分区失败: %w", topic, err)
+	}
+
+	var targets map[int32]int64
+	if beforeOffset != "" {
+		if targets, err = parsePartitionOffsets(beforeOffset, partitions); err != nil {
+			return err
+		}
+	} else {
+		ts, err := parseTimestamp(beforeTimestamp)
+		if err != nil {
+			return err
+		}
+		targets = make(map[int32]int64, len(partitions))
+		for _, p := range partitions {
+			offset, err := client.GetOffset(topic, p, ts)
+			if err != nil {
+				return fmt.Errorf("查询 %s-%d 的 offset 失败: %w", topic, p, err)
+			}
+			// 该时间之后没有消息时返回 -1，即全部消息都早于该时间
+			if offset < 0 {
+				offset = sarama.OffsetNewest
+			}
+			targets[p] = offset
+		}
+	}
+
+	type purge struct {
+		partition     int32
+		oldest, until int64
+	}
+	var purges []purge
+	deletions := make(map[int32]int64)
+	for p, target := range targets {
+		oldest, err := client.GetOffset(topic, p, sarama.OffsetOldest)
+		if err != nil {
+			return fmt.Errorf("查询 %s-%d 的最早 offset 失败: %w", topic, p, err)
+		}
+		newest, err := client.GetOffset(topic, p, sarama.OffsetNewest)
+		if err != nil {
+			return fmt.Errorf("查询 %s-%d 的最新 offset 失败: %w", topic, p, err)
+		}
+		if target == sarama.OffsetNewest || target > newest {
+			target = newest
+		}
+		if target <= oldest {
+			continue
+		}
+		deletions[p] = target
+		purges = append(purges, purge{partition: p, oldest: oldest, until: target})
+	}
+	if len(deletions) == 0 {
+		fmt.Println("ℹ️  没有需要删除的消息")
+		return nil
+	}
+
+	admin, err := newAdmin(conn)
+	if err != nil {
+		return err
+	}
+	defer admin.Close()
+
+	if err := admin.DeleteRecords(topic, deletions); err != nil {
+		return fmt.Errorf("删除 topic %s 的消息失败: %w", topic, err)
+	}
+
+	sort.Slice(purges, func(i, j int) bool { return purges[i].partition < purges[j].partition })
+	var total int64
+	for _, p := range purges {
+		fmt.Printf("✂️  %s-%d: %d -> %d，删除 %d 条\n", topic, p.partition, p.oldest, p.until, p.until-p.oldest)
+		total += p.until - p.oldest
+	}
+	fmt.Printf("共删除 %d 条消息\n", total)
+	return nil
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/IBM/sarama"
+
+	"kafka-topicctl/topicctl"
+)
+
+// topicThrottleConfigs 是分区重分配后残留在 topic 上的限流配置
+var topicThrottleConfigs = []string{
+	"leader.replication.throttled.replicas",
+	"follower.replication.throttled.replicas",
+}
+
+// brokerThrottleConfigs 是分区重分配时设置在 broker 上的动态限流速率
+var brokerThrottleConfigs = []string{
+	"leader.replication.throttled.rate",
+	"follower.replication.throttled.rate",
+}
+
+// deleteEntries 为 keys 生成 DELETE 操作
+func deleteEntries(keys []string) map[string]sarama.IncrementalAlterConfigsEntry {
+	entries := make(map[string]sarama.IncrementalAlterConfigsEntry, len(keys))
+	for _, k := range keys {
+		entries[k] = sarama.IncrementalAlterConfigsEntry{Operation: sarama.IncrementalAlterConfigsOperationDelete}
+	}
+	return entries
+}
+
+// unthrottle 删除通过过滤的 topic 上的限流副本配置；brokers 时同时删除每个 broker 上动态设置的限流速率。
+// 只删除实际存在的配置项，返回处理的 topic 数和 broker 数
+func unthrottle(conn *Config, filter *topicctl.Filter, brokers bool) (int, int, error) {
+	admin, err := newAdmin(conn)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer admin.Close()
+
+	topics, err := topicctl.ListTopics(admin, filter)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	topicCount := 0
+	for _, t := range topics {
+		var keys []string
+		for _, k := range topicThrottleConfigs {
+			if _, ok := t.Configs[k]; ok {
+				keys = append(keys, k)
+			}
+		}
+		if len(keys) == 0 {
+			continue
+		}
+		if err := admin.IncrementalAlterConfig(sarama.TopicResource, t.Name, deleteEntries(keys), false); err != nil {
+			return topicCount, 0, fmt.Errorf("删除 topic %s 的限流配置失败: %w", t.Name, err)
+		}
+		for _, k := range keys {
+			fmt.Printf("🧹 topic %s: 删除 %s=%s\n", t.Name, k, t.Configs[k])
+		}
+		topicCount++
+	}
+	if !brokers {
+		return topicCount, 0, nil
+	}
+
+	ids, err := allBrokerIDs(admin)
+	if err != nil {
+		return topicCount, 0, err
+	}
+	brokerCount := 0
+	for _, id := range ids {
+		entries, err := describeResourceConfig(admin, configResource{
+			res:   sarama.ConfigResource{Type: sarama.BrokerResource, Name: strconv.Itoa(int(id))},
+			label: fmt.Sprintf("broker %d", id),
+		})
+		if err != nil {
+			return topicCount, brokerCount, err
+		}
+		current := make(map[string]sarama.ConfigEntry, len(entries))
+		for _, e := range entries {
+			current[e.Name] = e
+		}
+
+		var keys []string
+		for _, k := range brokerThrottleConfigs {
+			if e, ok := current[k]; ok && e.Source == sarama.SourceDynamicBroker {
+				keys = append(keys, k)
+			}
+		}
+		if len(keys) == 0 {
+			continue
+		}
+		if err := admin.IncrementalAlterConfig(sarama.BrokerResource, strconv.Itoa(int(id)), deleteEntries(keys), false); err != nil {
+			return topicCount, brokerCount, fmt.Errorf("删除 broker %d 的限流配置失败: %w", id, err)
+		}
+		for _, k := range keys {
+			fmt.Printf("🧹 broker %d: 删除 %s=%s\n", id, k, current[k].Value)
+		}
+		brokerCount++
+	}
+	return topicCount, brokerCount, nil
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"kafka-topicctl/topicctl"
+)
+
+// timestampedName 在 out 的扩展名（含 .gz / .zst）之前插入时间戳，如 topics.json -> topics-20240101T150405.json
+func timestampedName(out, compressExt string, t time.Time) string {
+	base := strings.TrimSuffix(out, compressExt)
+	ext := filepath.Ext(base)
+	return strings.TrimSuffix(base, ext) + "-" + t.Format("20060102T150405") + ext + compressExt
+}
+
+// watchExport 每隔 interval 导出一次，只有 topic 列表的 SHA-256 与上一次不同时才写出带时间戳的新文件；
+// 某一次导出失败只告警，下个周期重试；ctx 取消后退出
+func watchExport(ctx context.Context, conn *Config, out, format, compression string, interval time.Duration, opts topicctl.ExportOptions) error {
+	admin, err := newAdmin(conn)
+	if err != nil {
+		return err
+	}
+	defer admin.Close()
+
+	if opts.ActiveWithin > 0 {
+		client, err := newClient(conn)
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+		opts.Client = client
+	}
+
+	ext, err := topicctl.CompressExt(compression)
+	if err != nil {
+		return err
+	}
+
+	opts.KafkaVersion = conn.KafkaVersion
+	var last string
+	for {
+		file, err := topicctl.ExportTopics(ctx, admin, opts)
+		if err != nil && ctx.Err() != nil {
+			return nil
+		}
+		if err != nil {
+			lg.log(topicctl.Event{
+				Action: "watch", Status: "warning", Error: err.Error(),
+				Message: fmt.Sprintf("⚠️  导出失败，%s 后重试: %v", interval, err),
+			})
+		}
+		sum := last
+		if err == nil {
+			if sum, err = topicctl.Hash(file.Topics); err != nil {
+				return err
+			}
+		}
+
+		if sum != last {
+			name := timestampedName(out, ext, time.Now())
+			if err := topicctl.WriteFileCompressed(name, format, compression, file); err != nil {
+				return err
+			}
+			message := fmt.Sprintf("📸 首次快照: %s (%d 个 topic)", name, len(file.Topics))
+			if last != "" {
+				message = fmt.Sprintf("🔔 检测到变化，已写入 %s (%d 个 topic)", name, len(file.Topics))
+			}
+			lg.log(topicctl.Event{Action: "watch", Status: "changed", Detail: name, Message: message})
+			last = sum
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(interval):
+		}
+	}
+}